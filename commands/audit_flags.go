@@ -0,0 +1,9 @@
+package commands
+
+import "github.com/spf13/cobra"
+
+// addAuditFlags adds the flags recorded as audit annotations on the objects modified by a command, see models.NewAuditParameters.
+func addAuditFlags(cmd *cobra.Command) { // coverage-ignore (trivial)
+	cmd.Flags().String("reason", "", "The reason for the operation, recorded on the modified objects")
+	cmd.Flags().String("ticket", "", "An optional ticket reference for the operation, recorded on the modified objects")
+}
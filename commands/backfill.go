@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/internal"
+	"github.com/spf13/cobra"
+)
+
+// BackfillCommand is the interface for the backfill command, which allows users to inspect and clean up the backfill requests in the cluster.
+type BackfillCommand interface {
+	internal.GenericCommand
+}
+
+// NewBackfillCommand creates a new instance of the BackfillCommand, which includes the list and gc subcommands.
+func NewBackfillCommand(listCommand BackfillListCommand, gcCommand BackfillGCCommand) BackfillCommand { // coverage-ignore (trivial)
+	cmd := cobra.Command{
+		Use:   "backfill",
+		Short: "Inspect and clean up backfill requests",
+	}
+	cmd.AddCommand(listCommand.GetCommand())
+	cmd.AddCommand(gcCommand.GetCommand())
+	return internal.NewGenericCommand(&cmd)
+}
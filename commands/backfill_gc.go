@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/internal"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// BackfillGCCommand is a command that deletes completed backfill requests.
+type BackfillGCCommand interface {
+	internal.GenericCommand
+}
+
+// NewBackfillGCCommand creates a new instance of the BackfillGCCommand, which deletes completed backfill requests by age and retention count per stream.
+func NewBackfillGCCommand(backfillService interfaces.BackfillService, configFlags *genericclioptions.ConfigFlags) BackfillGCCommand { // coverage-ignore (tested by integration tests)
+	cmd := cobra.Command{
+		Use:   "gc [--older-than <duration>] [--keep-last <n>] [--stream-class <stream-class>] [--all-namespaces]",
+		Args:  cobra.NoArgs,
+		Short: "Delete completed backfill requests by age and retention count per stream",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			parameters, err := models.NewBackfillGCParameters(cmd, configFlags)
+			if err != nil {
+				return err
+			}
+			return backfillService.GC(cmd.Context(), parameters)
+		},
+	}
+
+	cmd.Flags().String("older-than", "", "Only delete completed backfill requests created before the given duration, e.g. 7d or 12h")
+	cmd.Flags().Int("keep-last", 0, "Keep the given number of the most recent completed backfill requests of each stream")
+	cmd.Flags().String("stream-class", "", "Only delete backfill requests of the given stream class")
+	cmd.Flags().BoolP("all-namespaces", "A", false, "Delete backfill requests across all namespaces")
+	addDryRunFlag(&cmd)
+	addOutputFlag(&cmd)
+
+	return internal.NewGenericCommand(&cmd)
+}
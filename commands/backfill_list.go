@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/internal"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/logging"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// BackfillListCommand is a command that lists backfill requests and their state.
+type BackfillListCommand interface {
+	internal.GenericCommand
+}
+
+// NewBackfillListCommand creates a new instance of the BackfillListCommand, which lists backfill requests, optionally filtered by stream class, stream id and completion state.
+func NewBackfillListCommand(backfillService interfaces.BackfillService, configFlags *genericclioptions.ConfigFlags) BackfillListCommand { // coverage-ignore (tested by integration tests)
+	cmd := cobra.Command{
+		Use:   "list [--stream-class <stream-class>] [--stream-id <stream-id>] [--all-namespaces] [--active|--completed]",
+		Args:  cobra.NoArgs,
+		Short: "List backfill requests with their stream, completion state and age",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			parameters, err := models.NewBackfillListParameters(cmd, configFlags)
+			if err != nil {
+				return err
+			}
+
+			inventory, err := backfillService.List(cmd.Context(), parameters)
+			if err != nil {
+				return err
+			}
+
+			return logging.PrintList(parameters.Output, inventory.Table(), inventory.Objects(), os.Stdout)
+		},
+	}
+
+	cmd.Flags().String("stream-class", "", "Filter by stream class")
+	cmd.Flags().String("stream-id", "", "Filter by stream id")
+	cmd.Flags().BoolP("all-namespaces", "A", false, "List backfill requests across all namespaces")
+	cmd.Flags().Bool("active", false, "Only list backfill requests that are not completed")
+	cmd.Flags().Bool("completed", false, "Only list completed backfill requests")
+	addOutputFlag(&cmd)
+
+	return internal.NewGenericCommand(&cmd)
+}
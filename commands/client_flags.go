@@ -0,0 +1,21 @@
+package commands
+
+import (
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/rest"
+)
+
+// defaultChunkSize is the default number of objects returned by a single list request, the same as kubectl's.
+const defaultChunkSize = 500
+
+// addClientFlags adds the --qps, --burst and --chunk-size flags shared by all commands, bound to the provided
+// models.ClientParameters, and validates them before any command runs.
+func addClientFlags(cmd *cobra.Command, parameters *models.ClientParameters) { // coverage-ignore (trivial)
+	cmd.PersistentFlags().Float32Var(&parameters.QPS, "qps", rest.DefaultQPS, "The maximum number of requests per second sent to the API server")
+	cmd.PersistentFlags().IntVar(&parameters.Burst, "burst", rest.DefaultBurst, "The maximum number of requests sent to the API server at once, above --qps")
+	cmd.PersistentFlags().Int64Var(&parameters.ChunkSize, "chunk-size", defaultChunkSize, "Return large lists of streams in chunks rather than all at once. Pass 0 to disable")
+	cmd.PersistentPreRunE = func(*cobra.Command, []string) error {
+		return parameters.Validate()
+	}
+}
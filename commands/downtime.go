@@ -10,9 +10,10 @@ type DowntimeCommand interface {
 	internal.GenericCommand
 }
 
-// NewDowntimeCommand creates a new instance of the DowntimeCommand, which includes the declare and stop subcommands.
+// NewDowntimeCommand creates a new instance of the DowntimeCommand, which includes the declare, stop, expire, list and details subcommands.
 func NewDowntimeCommand(command DowntimeDeclareCommand,
 	stopCommand DowntimeStopCommand,
+	expireCommand DowntimeExpireCommand,
 	listCommand DowntimeListCommand,
 	detailsCommand DowntimeDetailsCommand) DowntimeCommand { // coverage-ignore (trivial)
 
@@ -22,6 +23,7 @@ func NewDowntimeCommand(command DowntimeDeclareCommand,
 	}
 	cmd.AddCommand(command.GetCommand())
 	cmd.AddCommand(stopCommand.GetCommand())
+	cmd.AddCommand(expireCommand.GetCommand())
 	cmd.AddCommand(listCommand.GetCommand())
 	cmd.AddCommand(detailsCommand.GetCommand())
 	return internal.NewGenericCommand(&cmd)
@@ -16,9 +16,18 @@ type DowntimeDeclareCommand interface {
 // NewDowntimeDeclareCommand creates a new instance of the DowntimeDeclareCommand, which allows users to temporarily stop a stream or a list of streams.
 func NewDowntimeDeclareCommand(ds interfaces.DowntimeService, configFlags *genericclioptions.ConfigFlags) DowntimeDeclareCommand { // coverage-ignore (trivial)
 	cmd := cobra.Command{
-		Use:   "declare <stream-class> <mask> <key>",
-		Args:  cobra.ExactArgs(3),
+		Use:   "declare [<stream-class> [<prefix>]] <key> [--prefix <prefix>] [--selector <selector>] [--regex <regex>] [--exclude <regex>] [--from-file <file>] [--all] [--all-namespaces] [--duration <duration>|--until <time>]",
+		Args:  cobra.RangeArgs(1, 3),
 		Short: "Begin downtime for a stream or a list of streams, use the <key> parameter to resume the stream(s) later",
+		Long: `Begin downtime for a stream or a list of streams, use the <key> parameter to resume the stream(s) later.
+
+The command accepts one to three arguments:
+  declare <key>                               Select the streams of all stream classes, narrowed down by the flags
+  declare <stream-class> <key>                Select the streams of the stream class, narrowed down by the flags
+  declare <stream-class> <prefix> <key>       Select the streams of the stream class with names starting with the prefix
+
+A selection that is not narrowed down by a prefix, --selector, --regex or --from-file suspends all streams of the
+stream class, or of all stream classes, and must be confirmed with --all.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			parameters, err := models.NewDowntimeDeclareParameters(cmd, args, configFlags)
 			if err != nil {
@@ -27,5 +36,18 @@ func NewDowntimeDeclareCommand(ds interfaces.DowntimeService, configFlags *gener
 			return ds.DeclareDowntime(cmd.Context(), parameters)
 		},
 	}
+	cmd.Flags().String("prefix", "", "Select streams with names starting with the given prefix")
+	cmd.Flags().Bool("all", false, "Confirm the selection of all streams of the stream class, or of all stream classes without a stream class")
+	cmd.Flags().BoolP("all-namespaces", "A", false, "Select streams across all namespaces")
+	cmd.Flags().StringP("selector", "l", "", "Select streams by label selector, supports '=', '==', '!=', 'in' and 'notin'")
+	cmd.Flags().String("regex", "", "Select streams with names matching the regular expression")
+	cmd.Flags().String("exclude", "", "Never select streams with names matching the regular expression")
+	cmd.Flags().String("from-file", "", "Select the streams listed in the file, one stream id per line, use '-' to read the list from stdin")
+	cmd.Flags().String("duration", "", "Let 'downtime expire' end the downtime after the given duration, e.g. 2h or 3d")
+	cmd.Flags().String("until", "", "Let 'downtime expire' end the downtime after the given RFC3339 timestamp, e.g. 2006-01-02T15:04:05Z")
+	addDryRunFlag(&cmd)
+	addOutputFlag(&cmd)
+	addAuditFlags(&cmd)
+	addQueueFlags(&cmd)
 	return internal.NewGenericCommand(&cmd)
 }
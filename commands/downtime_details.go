@@ -33,7 +33,7 @@ func NewDowntimeDetailsCommand(ds interfaces.DowntimeService) DowntimeDetailsCom
 				return err
 			}
 
-			err = logging.TablePrinter().PrintObj(dts.Details(), os.Stdout)
+			err = logging.PrintList(parameters.Output, dts.Details(), dts.Objects(), os.Stdout)
 			if err != nil {
 				return err
 			}
@@ -44,6 +44,7 @@ func NewDowntimeDetailsCommand(ds interfaces.DowntimeService) DowntimeDetailsCom
 
 	// add --stream-class flag so callers can filter by stream class without positional args
 	cmd.Flags().String("stream-class", "", "Filter by stream class")
+	addOutputFlag(&cmd)
 
 	return internal.NewGenericCommand(&cmd)
 }
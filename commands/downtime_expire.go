@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/internal"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// DowntimeExpireCommand is a command to end the downtimes that have passed their expiry
+type DowntimeExpireCommand interface {
+	internal.GenericCommand
+}
+
+// NewDowntimeExpireCommand creates a new instance of the DowntimeExpireCommand, which drops the downtime keys that have passed their expiry and resumes the streams without a remaining key.
+func NewDowntimeExpireCommand(ds interfaces.DowntimeService, configFlags *genericclioptions.ConfigFlags) DowntimeExpireCommand { // coverage-ignore (trivial)
+	cmd := cobra.Command{
+		Use:   "expire [--stream-class <stream-class>]",
+		Args:  cobra.NoArgs,
+		Short: "Drop the downtime keys that have passed the expiry set with --duration or --until and resume the streams without a remaining key, e.g. from a CronJob",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			parameters, err := models.NewDowntimeExpireParameters(cmd, configFlags)
+			if err != nil {
+				return err
+			}
+			return ds.ExpireDowntimes(cmd.Context(), parameters)
+		},
+	}
+	cmd.Flags().String("stream-class", "", "Only resume streams of the given stream class")
+	addDryRunFlag(&cmd)
+	addOutputFlag(&cmd)
+	addAuditFlags(&cmd)
+	addQueueFlags(&cmd)
+	return internal.NewGenericCommand(&cmd)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/internal"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
 	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
 // DowntimeStopCommand is a command to stop downtime for a stream or a list of streams, use the <key> parameter to identify the stream(s) to resume
@@ -13,18 +14,22 @@ type DowntimeStopCommand interface {
 }
 
 // NewDowntimeStopCommand creates a new instance of the DowntimeStopCommand, which allows users to stop downtime for a stream or a list of streams.
-func NewDowntimeStopCommand(ds interfaces.DowntimeService) DowntimeStopCommand { // coverage-ignore (trivial)
+func NewDowntimeStopCommand(ds interfaces.DowntimeService, configFlags *genericclioptions.ConfigFlags) DowntimeStopCommand { // coverage-ignore (trivial)
 	cmd := cobra.Command{
-		Use:   "stop <stream-class> <key>",
-		Args:  cobra.ExactArgs(2),
+		Use:   "stop [<stream-class>] <key>",
+		Args:  cobra.RangeArgs(1, 2),
 		Short: "Stop downtime for a stream or a list of streams, use the <key> parameter to identify the stream(s) to resume",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			parameters, err := models.NewDowntimeStopParameters(cmd, args)
+			parameters, err := models.NewDowntimeStopParameters(cmd, args, configFlags)
 			if err != nil {
 				return err
 			}
 			return ds.StopDowntime(cmd.Context(), parameters)
 		},
 	}
+	addDryRunFlag(&cmd)
+	addOutputFlag(&cmd)
+	addAuditFlags(&cmd)
+	addQueueFlags(&cmd)
 	return internal.NewGenericCommand(&cmd)
 }
@@ -0,0 +1,13 @@
+package commands
+
+import (
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	"github.com/spf13/cobra"
+)
+
+// addDryRunFlag adds the --dry-run flag used by mutating commands, see models.NewDryRunStrategy. A bare --dry-run
+// means the client strategy, as in kubectl.
+func addDryRunFlag(cmd *cobra.Command) { // coverage-ignore (trivial)
+	cmd.Flags().String("dry-run", "none", "Must be \"none\", \"client\", or \"server\". If client strategy, only print the objects that would be modified, without sending them. If server strategy, submit server-side request without persisting the resource. A bare --dry-run means client, so the strategy must be given as --dry-run=server: with a space, 'server' is read as a positional argument.")
+	cmd.Flags().Lookup("dry-run").NoOptDefVal = string(models.DryRunClient)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	streamingv1 "github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackfillInventory defines an interface for presenting the backfill requests found in the cluster.
+type BackfillInventory interface {
+
+	// Table returns a table with a row per backfill request, including its stream class, stream id and completion state.
+	Table() *v1.Table
+
+	// Objects returns the backfill requests included in the inventory as a list, used for structured output formats.
+	Objects() *streamingv1.BackfillRequestList
+}
@@ -11,4 +11,13 @@ type BackfillService interface {
 
 	// Backfill performs a backfill operation for a stream based on the provided command and arguments.
 	Backfill(ctx context.Context, parameters *models.BackfillParameters) error
+
+	// Cancel deletes the active backfill request of a stream, so the operator terminates the backfill job.
+	Cancel(ctx context.Context, parameters *models.BackfillCancelParameters) error
+
+	// List retrieves the backfill requests in the cluster, optionally filtered by stream class, stream id, namespace and completion state.
+	List(ctx context.Context, parameters *models.BackfillListParameters) (BackfillInventory, error)
+
+	// GC deletes the completed backfill requests that are older than the retention period and not among the most recent ones of their stream.
+	GC(ctx context.Context, parameters *models.BackfillGCParameters) error
 }
@@ -14,4 +14,7 @@ type ClientProvider interface {
 
 	// ProvideUnstructuredClient returns a controller-runtime client that can be used for unstructured operations.
 	ProvideUnstructuredClient() (client.Client, error)
+
+	// ChunkSize returns the maximum number of objects returned by a single list request, zero for no limit.
+	ChunkSize() int64
 }
@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // DowntimeSummary defines an interface for summarizing downtime information, including counts and details of downtime events.
@@ -18,4 +19,7 @@ type DowntimeSummary interface {
 
 	// DetailsRaw returns a raw map of downtime event details, categorized by relevant criteria, without any formatting.
 	DetailsRaw() map[string][]string
+
+	// Objects returns a list with an object per downtime key, including its stream count, begin time, expiry and streams, used for structured output formats.
+	Objects() *unstructured.UnstructuredList
 }
@@ -15,6 +15,9 @@ type DowntimeService interface {
 	// StopDowntime ends an active downtime period for specified streams based on the provided command and arguments.
 	StopDowntime(ctx context.Context, parameters *models.DowntimeStopParameters) error
 
+	// ExpireDowntimes drops every downtime key that has passed its expiry, and ends the downtime of the streams without a remaining key.
+	ExpireDowntimes(ctx context.Context, parameters *models.DowntimeExpireParameters) error
+
 	// GetSummary retrieves a list of active downtime keys in the cluster, optionally filtered by stream class.
 	GetSummary(ctx context.Context, parameters *models.DowntimeSummaryParameters) (DowntimeSummary, error)
 }
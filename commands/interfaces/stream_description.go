@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"io"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// StreamDescription defines an interface for presenting the state of a single stream together with its related resources.
+type StreamDescription interface {
+
+	// Print writes a human-readable description of the stream to the provided writer, similar to kubectl describe.
+	Print(w io.Writer) error
+
+	// Raw returns the stream definition as an unstructured object, without any formatting.
+	Raw() *unstructured.Unstructured
+}
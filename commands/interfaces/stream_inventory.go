@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// StreamInventory defines an interface for presenting the streams found in the cluster across stream classes.
+type StreamInventory interface {
+
+	// Table returns a table with a row per stream, including its class, phase, suspension state and downtime information.
+	Table() *v1.Table
+
+	// Raw returns the stream definitions included in the inventory as unstructured objects, without any formatting.
+	Raw() []*unstructured.Unstructured
+
+	// Objects returns the stream definitions included in the inventory as a list, used for structured output formats.
+	Objects() *unstructured.UnstructuredList
+}
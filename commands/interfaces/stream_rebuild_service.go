@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+)
+
+// StreamRebuildService defines the maintenance workflow that re-loads a stream with a backfill.
+type StreamRebuildService interface {
+
+	// Rebuild stops the stream, backfills it and waits for the backfill to complete, then starts the stream again.
+	// If a step fails, the stream is returned to its original suspension state.
+	Rebuild(ctx context.Context, parameters *models.RebuildParameters) error
+}
@@ -14,4 +14,10 @@ type StreamService interface {
 
 	// Stop terminates an active stream based on the provided command and arguments.
 	Stop(ctx context.Context, parameters *models.StopParameters) error
+
+	// List retrieves the streams in the cluster, optionally filtered by stream class and namespace.
+	List(ctx context.Context, parameters *models.StreamListParameters) (StreamInventory, error)
+
+	// Describe retrieves the stream definition together with its backfill requests, jobs, pods and events.
+	Describe(ctx context.Context, parameters *models.StreamDescribeParameters) (StreamDescription, error)
 }
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// AuditParameters represents the audit information recorded on the objects modified by a command.
+type AuditParameters struct {
+	Reason         string // The reason for the operation, given by the user.
+	Ticket         string // The optional ticket reference for the operation, given by the user.
+	KubeconfigUser string // The user of the current kubeconfig context, used as the actor if the cluster cannot report the authenticated user.
+}
+
+// NewAuditParameters creates a new instance of AuditParameters based on the --reason and --ticket flags and the current kubeconfig context.
+func NewAuditParameters(cmd *cobra.Command, configFlags *genericclioptions.ConfigFlags) (AuditParameters, error) { // coverage-ignore (tested in integration tests)
+	reason, err := cmd.Flags().GetString("reason")
+	if err != nil {
+		return AuditParameters{}, err
+	}
+
+	ticket, err := cmd.Flags().GetString("ticket")
+	if err != nil {
+		return AuditParameters{}, err
+	}
+
+	rawConfig, err := configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return AuditParameters{}, err
+	}
+
+	currentContext := rawConfig.CurrentContext
+	if configFlags.Context != nil && *configFlags.Context != "" {
+		currentContext = *configFlags.Context
+	}
+
+	var user string
+	if kubeContext, ok := rawConfig.Contexts[currentContext]; ok {
+		user = kubeContext.AuthInfo
+	}
+	if configFlags.AuthInfoName != nil && *configFlags.AuthInfoName != "" {
+		user = *configFlags.AuthInfoName
+	}
+
+	return AuditParameters{Reason: reason, Ticket: ticket, KubeconfigUser: user}, nil
+}
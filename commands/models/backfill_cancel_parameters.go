@@ -0,0 +1,62 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// BackfillCancelParameters represents the parameters required to cancel the active backfill request of a stream.
+type BackfillCancelParameters struct {
+	StreamClass string         // The class of the stream to cancel the backfill for.
+	StreamId    string         // The unique identifier of the stream to cancel the backfill for.
+	Namespace   string         // The namespace in which the stream is located.
+	Wait        bool           // Whether to wait for the backfill job to terminate before returning.
+	Timeout     time.Duration  // The maximum time to wait for the backfill job to terminate.
+	DryRun      DryRunStrategy // Whether to only print or server-side validate the deletion instead of deleting the backfill request.
+	Output      string         // The output format of the cancelled backfill request, see NewOutputFormat.
+}
+
+// NewBackfillCancelParameters creates a new instance of BackfillCancelParameters based on the provided command and arguments.
+func NewBackfillCancelParameters(cmd *cobra.Command, args []string, configFlags *genericclioptions.ConfigFlags) (*BackfillCancelParameters, error) { // coverage-ignore (tested in integration tests)
+	wait, err := cmd.Flags().GetBool("wait")
+	if err != nil {
+		return nil, err
+	}
+
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return nil, err
+	}
+
+	dryRun, err := NewDryRunStrategy(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	if wait && dryRun != DryRunNone {
+		return nil, fmt.Errorf("--wait cannot be combined with --dry-run")
+	}
+
+	output, err := NewOutputFormat(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, _, err := configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackfillCancelParameters{
+		StreamClass: args[0],
+		StreamId:    args[1],
+		Namespace:   namespace,
+		Wait:        wait,
+		Timeout:     timeout,
+		DryRun:      dryRun,
+		Output:      output,
+	}, nil
+}
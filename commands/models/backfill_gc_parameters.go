@@ -0,0 +1,90 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// BackfillGCParameters represents the parameters required to delete completed backfill requests.
+type BackfillGCParameters struct {
+	StreamClass string         // The optional stream class filter.
+	Namespace   string         // The namespace to delete backfill requests in. If empty, backfill requests from all namespaces are deleted.
+	OlderThan   time.Duration  // The minimum age of the deleted backfill requests. Zero means any age.
+	KeepLast    int            // The number of the most recent completed backfill requests kept per stream.
+	DryRun      DryRunStrategy // Whether to only print or server-side validate the deletion instead of deleting the backfill requests.
+	Output      string         // The output format of the deleted backfill requests, see NewOutputFormat.
+}
+
+// NewBackfillGCParameters creates a new instance of BackfillGCParameters based on the provided command and arguments.
+func NewBackfillGCParameters(cmd *cobra.Command, configFlags *genericclioptions.ConfigFlags) (*BackfillGCParameters, error) { // coverage-ignore (tested in integration tests)
+	streamClass, err := cmd.Flags().GetString("stream-class")
+	if err != nil {
+		return nil, err
+	}
+
+	olderThanValue, err := cmd.Flags().GetString("older-than")
+	if err != nil {
+		return nil, err
+	}
+
+	keepLast, err := cmd.Flags().GetInt("keep-last")
+	if err != nil {
+		return nil, err
+	}
+
+	if olderThanValue == "" && !cmd.Flags().Changed("keep-last") {
+		return nil, fmt.Errorf("specify --older-than, --keep-last or both")
+	}
+
+	if keepLast < 0 {
+		return nil, fmt.Errorf("--keep-last must not be negative")
+	}
+
+	var olderThan time.Duration
+	if olderThanValue != "" {
+		olderThan, err = ParseDuration(olderThanValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --older-than: %w", err)
+		}
+		if olderThan <= 0 {
+			return nil, fmt.Errorf("--older-than must be positive")
+		}
+	}
+
+	dryRun, err := NewDryRunStrategy(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := NewOutputFormat(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := &BackfillGCParameters{
+		StreamClass: streamClass,
+		OlderThan:   olderThan,
+		KeepLast:    keepLast,
+		DryRun:      dryRun,
+		Output:      output,
+	}
+
+	allNamespaces, err := cmd.Flags().GetBool("all-namespaces")
+	if err != nil {
+		return nil, err
+	}
+
+	if allNamespaces {
+		return parameters, nil
+	}
+
+	parameters.Namespace, _, err = configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return nil, err
+	}
+
+	return parameters, nil
+}
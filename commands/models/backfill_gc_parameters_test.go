@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newBackfillGCCommand(t *testing.T, args ...string) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("older-than", "", "")
+	cmd.Flags().Int("keep-last", 0, "")
+	cmd.Flags().String("stream-class", "", "")
+	cmd.Flags().BoolP("all-namespaces", "A", false, "")
+	cmd.Flags().String("dry-run", "none", "")
+	cmd.Flags().StringP("output", "o", "", "")
+	require.NoError(t, cmd.Flags().Parse(args))
+	return cmd
+}
+
+func Test_NewBackfillGCParameters_OlderThan(t *testing.T) {
+	cmd := newBackfillGCCommand(t, "--older-than", "1d12h", "-A")
+
+	parameters, err := NewBackfillGCParameters(cmd, genericclioptions.NewConfigFlags(false))
+
+	require.NoError(t, err)
+	require.Equal(t, "36h0m0s", parameters.OlderThan.String())
+}
+
+func Test_NewBackfillGCParameters_OlderThanNotPositive(t *testing.T) {
+	for _, value := range []string{"0d", "0s", "-1h"} {
+		cmd := newBackfillGCCommand(t, "--older-than", value, "-A")
+
+		_, err := NewBackfillGCParameters(cmd, genericclioptions.NewConfigFlags(false))
+
+		require.ErrorContains(t, err, "--older-than must be positive", value)
+	}
+}
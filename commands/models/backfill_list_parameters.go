@@ -0,0 +1,74 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// BackfillListParameters represents the parameters required to list backfill requests in the cluster.
+type BackfillListParameters struct {
+	StreamClass string // The optional stream class filter.
+	StreamId    string // The optional stream id filter.
+	Namespace   string // The namespace to list backfill requests in. If empty, backfill requests from all namespaces are listed.
+	Active      bool   // Whether to only list backfill requests that are not completed.
+	Completed   bool   // Whether to only list completed backfill requests.
+	Output      string // The output format, see NewOutputFormat.
+}
+
+// NewBackfillListParameters creates a new instance of BackfillListParameters based on the provided command and arguments.
+func NewBackfillListParameters(cmd *cobra.Command, configFlags *genericclioptions.ConfigFlags) (*BackfillListParameters, error) { // coverage-ignore (tested in integration tests)
+	streamClass, err := cmd.Flags().GetString("stream-class")
+	if err != nil {
+		return nil, err
+	}
+
+	streamId, err := cmd.Flags().GetString("stream-id")
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := cmd.Flags().GetBool("active")
+	if err != nil {
+		return nil, err
+	}
+
+	completed, err := cmd.Flags().GetBool("completed")
+	if err != nil {
+		return nil, err
+	}
+
+	if active && completed {
+		return nil, fmt.Errorf("--active and --completed cannot be combined")
+	}
+
+	output, err := NewOutputFormat(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := &BackfillListParameters{
+		StreamClass: streamClass,
+		StreamId:    streamId,
+		Active:      active,
+		Completed:   completed,
+		Output:      output,
+	}
+
+	allNamespaces, err := cmd.Flags().GetBool("all-namespaces")
+	if err != nil {
+		return nil, err
+	}
+
+	if allNamespaces {
+		return parameters, nil
+	}
+
+	parameters.Namespace, _, err = configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return nil, err
+	}
+
+	return parameters, nil
+}
@@ -2,6 +2,8 @@ package models
 
 import (
 	"fmt"
+	"time"
+
 	"github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -10,10 +12,19 @@ import (
 
 // BackfillParameters represents the parameters required to perform a backfill operation for a stream.
 type BackfillParameters struct {
-	StreamClass string // The class of the stream to backfill.
-	StreamId    string // The unique identifier of the stream to backfill.
-	Wait        bool   // Whether to wait for the backfill operation to complete before returning.
-	Namespace   string // The namespace in which the stream is located. If empty, the default namespace will be used.
+	StreamClass string          // The class of the stream to backfill.
+	StreamId    string          // The unique identifier of the stream to backfill.
+	Wait        bool            // Whether to wait for the backfill operation to complete before returning.
+	Replace     bool            // Whether to cancel the active backfill request of the stream, if any, before creating a new one.
+	Timeout     time.Duration   // The maximum time to wait for the backfill to complete and for the replaced backfill job to terminate. Zero means no timeout.
+	Namespace   string          // The namespace in which the stream is located. If empty, the default namespace will be used.
+	DryRun      DryRunStrategy  // Whether to only print or server-side validate the backfill request instead of creating it.
+	Output      string          // The output format of the backfill request, see NewOutputFormat.
+	Audit       AuditParameters // The audit information recorded on the backfill request.
+	MaxInFlight int             // The maximum number of backfills of the selection running at the same time. Zero means no limit.
+
+	// Selection is the set of streams to backfill in bulk. If nil, only the stream identified by StreamId is backfilled.
+	Selection *StreamSelection
 }
 
 // NewBackfillParameters creates a new instance of BackfillParameters based on the provided command and arguments.
@@ -24,6 +35,57 @@ func NewBackfillParameters(cmd *cobra.Command, args []string, configFlags *gener
 		return nil, err
 	}
 
+	replace, err := cmd.Flags().GetBool("replace")
+	if err != nil {
+		return nil, err
+	}
+
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return nil, err
+	}
+
+	dryRun, err := NewDryRunStrategy(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	if wait && dryRun != DryRunNone {
+		return nil, fmt.Errorf("--wait cannot be combined with --dry-run")
+	}
+
+	output, err := NewOutputFormat(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	audit, err := NewAuditParameters(cmd, configFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	maxInFlight, err := cmd.Flags().GetInt("max-in-flight")
+	if err != nil {
+		return nil, err
+	}
+
+	if maxInFlight < 0 {
+		return nil, fmt.Errorf("--max-in-flight must not be negative")
+	}
+
+	if maxInFlight > 0 && dryRun != DryRunNone {
+		return nil, fmt.Errorf("--max-in-flight cannot be combined with --dry-run")
+	}
+
+	selection, err := NewStreamSelection(cmd, args[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	if selection == nil && maxInFlight > 0 {
+		return nil, fmt.Errorf("--max-in-flight is only supported for a list of streams")
+	}
+
 	namespace, _, err := configFlags.ToRawKubeConfigLoader().Namespace()
 	if err != nil {
 		return nil, err
@@ -31,9 +93,18 @@ func NewBackfillParameters(cmd *cobra.Command, args []string, configFlags *gener
 
 	bfr := &BackfillParameters{
 		StreamClass: args[0],
-		StreamId:    args[1],
 		Wait:        wait,
+		Replace:     replace,
+		Timeout:     timeout,
 		Namespace:   namespace,
+		DryRun:      dryRun,
+		Output:      output,
+		Audit:       audit,
+		MaxInFlight: maxInFlight,
+		Selection:   selection,
+	}
+	if selection == nil {
+		bfr.StreamId = args[1]
 	}
 
 	return bfr, nil
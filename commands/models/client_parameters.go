@@ -0,0 +1,22 @@
+package models
+
+import "fmt"
+
+// ClientParameters represents the settings of the clients calling the Kubernetes API server, shared by all commands.
+// The fields are bound to the persistent flags of the root command, so they are only set once the flags are parsed.
+type ClientParameters struct {
+	QPS       float32 // The maximum number of requests per second sent to the API server.
+	Burst     int     // The maximum number of requests sent to the API server at once, above the QPS.
+	ChunkSize int64   // The maximum number of objects returned by a single list request, zero to list all objects at once.
+}
+
+// Validate returns an error if the rate limits cannot be enforced by the clients.
+func (p *ClientParameters) Validate() error {
+	if p.QPS <= 0 {
+		return fmt.Errorf("--qps must be positive")
+	}
+	if p.Burst < 1 {
+		return fmt.Errorf("--burst must be at least 1")
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ClientParameters_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		parameters ClientParameters
+		err        string
+	}{
+		{name: "defaults", parameters: ClientParameters{QPS: 5, Burst: 10}},
+		{name: "fractional qps", parameters: ClientParameters{QPS: 0.5, Burst: 1}},
+		{name: "zero qps", parameters: ClientParameters{QPS: 0, Burst: 10}, err: "--qps must be positive"},
+		{name: "negative qps", parameters: ClientParameters{QPS: -1, Burst: 10}, err: "--qps must be positive"},
+		{name: "zero burst", parameters: ClientParameters{QPS: 5, Burst: 0}, err: "--burst must be at least 1"},
+		{name: "negative burst", parameters: ClientParameters{QPS: 5, Burst: -1}, err: "--burst must be at least 1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.parameters.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
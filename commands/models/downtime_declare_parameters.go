@@ -1,28 +1,192 @@
 package models
 
 import (
+	"fmt"
+	"regexp"
+	"time"
+
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
 // DowntimeDeclareParameters represents the parameters required to perform a stop operation for a stream.
 type DowntimeDeclareParameters struct {
-	StreamClass string // The class of the stream to stop.
-	Prefix      string // The prefix of the stream to stop.
-	DowntimeKey string // The unique identifier of the downtime to declare.
-	Namespace   string // The namespace of the stream to stop.
+	StreamClass string          // The class of the stream to stop. If empty, streams of all stream classes are selected.
+	Prefix      string          // The prefix of the stream to stop.
+	DowntimeKey string          // The unique identifier of the downtime to declare.
+	Namespace   string          // The namespace of the stream to stop. If empty, streams from all namespaces are selected.
+	DryRun      DryRunStrategy  // Whether to only print or server-side validate the changes instead of persisting them.
+	Output      string          // The output format of the modified streams, see NewOutputFormat.
+	Audit       AuditParameters // The audit information recorded on the modified streams.
+	Queue       QueueParameters // The settings of the processing of the stream list.
+	Expiry      time.Time       // The time after which the downtime can be ended by `downtime expire`. Zero means the downtime does not expire.
+
+	// LabelSelector is the label selector applied on the server side when listing the streams.
+	LabelSelector string
+
+	// Regex is the regular expression the names of the selected streams must match.
+	Regex string
+
+	// Exclude is the regular expression matching the names of the streams that are never selected.
+	Exclude string
+
+	// StreamIds is the explicit list of the stream identifiers to select, read with --from-file.
+	StreamIds []string
 }
 
 // NewDowntimeDeclareParameters creates a new instance of StopParameters based on the provided command and arguments.
-func NewDowntimeDeclareParameters(_ *cobra.Command, args []string, configFlags *genericclioptions.ConfigFlags) (*DowntimeDeclareParameters, error) { // coverage-ignore (tested in integration tests)
-	namespace, _, err := configFlags.ToRawKubeConfigLoader().Namespace()
+func NewDowntimeDeclareParameters(cmd *cobra.Command, args []string, configFlags *genericclioptions.ConfigFlags) (*DowntimeDeclareParameters, error) { // coverage-ignore (tested in integration tests)
+	dryRun, err := NewDryRunStrategy(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := NewOutputFormat(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	audit, err := NewAuditParameters(cmd, configFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	queue, err := NewQueueParameters(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	expiry, err := newDowntimeExpiry(cmd, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := cmd.Flags().GetString("selector")
+	if err != nil {
+		return nil, err
+	}
+
+	regex, err := cmd.Flags().GetString("regex")
+	if err != nil {
+		return nil, err
+	}
+
+	exclude, err := cmd.Flags().GetString("exclude")
+	if err != nil {
+		return nil, err
+	}
+
+	for flag, pattern := range map[string]string{"--regex": regex, "--exclude": exclude} {
+		if _, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", flag, err)
+		}
+	}
+
+	fromFile, err := cmd.Flags().GetString("from-file")
+	if err != nil {
+		return nil, err
+	}
+
+	var streamIds []string
+	if fromFile != "" {
+		streamIds, err = readStreamIds(cmd.InOrStdin(), fromFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	prefix, err := cmd.Flags().GetString("prefix")
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return nil, err
+	}
+
+	// declare [<stream-class> [<prefix>]] <key>, a selection that is not narrowed down by a prefix or selection flags
+	// suspends all streams of the stream class, or of all stream classes, and must be confirmed with --all
+	streamClass, key := "", args[len(args)-1]
+	if len(args) > 1 {
+		streamClass = args[0]
+	}
+	if len(args) == 3 {
+		if prefix != "" {
+			return nil, fmt.Errorf("the prefix cannot be given both as an argument and with --prefix")
+		}
+		prefix = args[1]
+	}
+	if prefix == "" && selector == "" && regex == "" && fromFile == "" && !all {
+		if streamClass == "" {
+			return nil, fmt.Errorf("specify --prefix, --selector, --regex or --from-file, or --all to select the streams of all stream classes")
+		}
+		return nil, fmt.Errorf("specify a prefix, --selector, --regex or --from-file, or --all to select all streams of stream class %s", streamClass)
+	}
+
+	allNamespaces, err := cmd.Flags().GetBool("all-namespaces")
 	if err != nil {
 		return nil, err
 	}
+
+	namespace := ""
+	if !allNamespaces {
+		namespace, _, err = configFlags.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &DowntimeDeclareParameters{
-		StreamClass: args[0],
-		Prefix:      args[1],
-		DowntimeKey: args[2],
-		Namespace:   namespace,
+		StreamClass:   streamClass,
+		Prefix:        prefix,
+		DowntimeKey:   key,
+		Namespace:     namespace,
+		DryRun:        dryRun,
+		Output:        output,
+		Audit:         audit,
+		Expiry:        expiry,
+		Queue:         queue,
+		LabelSelector: selector,
+		Regex:         regex,
+		Exclude:       exclude,
+		StreamIds:     streamIds,
 	}, nil
 }
+
+// newDowntimeExpiry reads the expiry of the downtime from the --duration or the --until flag.
+func newDowntimeExpiry(cmd *cobra.Command, now time.Time) (time.Time, error) { // coverage-ignore (tested in integration tests)
+	durationValue, err := cmd.Flags().GetString("duration")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	untilValue, err := cmd.Flags().GetString("until")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	switch {
+	case durationValue != "" && untilValue != "":
+		return time.Time{}, fmt.Errorf("--duration and --until cannot be combined")
+	case durationValue != "":
+		duration, err := ParseDuration(durationValue)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid --duration: %w", err)
+		}
+		if duration <= 0 {
+			return time.Time{}, fmt.Errorf("--duration must be positive")
+		}
+		return now.Add(duration).UTC(), nil
+	case untilValue != "":
+		until, err := time.Parse(time.RFC3339, untilValue)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid --until, use an RFC3339 timestamp like 2006-01-02T15:04:05Z: %w", err)
+		}
+		if !until.After(now) {
+			return time.Time{}, fmt.Errorf("--until must be in the future")
+		}
+		return until.UTC(), nil
+	default:
+		return time.Time{}, nil
+	}
+}
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newDowntimeDeclareCommand(t *testing.T, args ...string) (*cobra.Command, []string) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("prefix", "", "")
+	cmd.Flags().Bool("all", false, "")
+	cmd.Flags().BoolP("all-namespaces", "A", false, "")
+	cmd.Flags().StringP("selector", "l", "", "")
+	cmd.Flags().String("regex", "", "")
+	cmd.Flags().String("exclude", "", "")
+	cmd.Flags().String("from-file", "", "")
+	cmd.Flags().String("duration", "", "")
+	cmd.Flags().String("until", "", "")
+	cmd.Flags().String("dry-run", "none", "")
+	cmd.Flags().StringP("output", "o", "", "")
+	cmd.Flags().String("reason", "", "")
+	cmd.Flags().String("ticket", "", "")
+	cmd.Flags().Int("max-attempts", 5, "")
+	cmd.Flags().Int("concurrency", 1, "")
+	require.NoError(t, cmd.Flags().Parse(args))
+	return cmd, cmd.Flags().Args()
+}
+
+func Test_NewDowntimeDeclareParameters_Selection(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		streamClass string
+		prefix      string
+		key         string
+	}{
+		{
+			name:        "class, prefix and key",
+			args:        []string{"-A", "arcane-stream-mock", "sales-", "db-upgrade"},
+			streamClass: "arcane-stream-mock",
+			prefix:      "sales-",
+			key:         "db-upgrade",
+		},
+		{
+			name:        "class and key narrowed down by a flag",
+			args:        []string{"-A", "arcane-stream-mock", "db-upgrade", "--selector", "team=sales"},
+			streamClass: "arcane-stream-mock",
+			key:         "db-upgrade",
+		},
+		{
+			name:        "class and key confirmed with --all",
+			args:        []string{"-A", "arcane-stream-mock", "db-upgrade", "--all"},
+			streamClass: "arcane-stream-mock",
+			key:         "db-upgrade",
+		},
+		{
+			name:   "key narrowed down by --prefix",
+			args:   []string{"-A", "db-upgrade", "--prefix", "sales-"},
+			prefix: "sales-",
+			key:    "db-upgrade",
+		},
+		{
+			name: "key confirmed with --all",
+			args: []string{"-A", "db-upgrade", "--all"},
+			key:  "db-upgrade",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, args := newDowntimeDeclareCommand(t, tt.args...)
+
+			parameters, err := NewDowntimeDeclareParameters(cmd, args, genericclioptions.NewConfigFlags(false))
+
+			require.NoError(t, err)
+			require.Equal(t, tt.streamClass, parameters.StreamClass)
+			require.Equal(t, tt.prefix, parameters.Prefix)
+			require.Equal(t, tt.key, parameters.DowntimeKey)
+		})
+	}
+}
+
+func Test_NewDowntimeDeclareParameters_RequiresAll(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		error string
+	}{
+		{
+			name:  "key only",
+			args:  []string{"-A", "db-upgrade"},
+			error: "or --all to select the streams of all stream classes",
+		},
+		{
+			name:  "key with --exclude only",
+			args:  []string{"-A", "db-upgrade", "--exclude", "-critical$"},
+			error: "or --all to select the streams of all stream classes",
+		},
+		{
+			name:  "class and key, e.g. a forgotten prefix",
+			args:  []string{"-A", "arcane-stream-mock", "db-upgrade"},
+			error: "or --all to select all streams of stream class arcane-stream-mock",
+		},
+		{
+			name:  "class, empty prefix and key",
+			args:  []string{"-A", "arcane-stream-mock", "", "db-upgrade"},
+			error: "or --all to select all streams of stream class arcane-stream-mock",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, args := newDowntimeDeclareCommand(t, tt.args...)
+
+			_, err := NewDowntimeDeclareParameters(cmd, args, genericclioptions.NewConfigFlags(false))
+
+			require.ErrorContains(t, err, tt.error)
+		})
+	}
+}
+
+func Test_NewDowntimeDeclareParameters_PrefixTwice(t *testing.T) {
+	cmd, args := newDowntimeDeclareCommand(t, "-A", "arcane-stream-mock", "sales-", "db-upgrade", "--prefix", "orders-")
+
+	_, err := NewDowntimeDeclareParameters(cmd, args, genericclioptions.NewConfigFlags(false))
+
+	require.ErrorContains(t, err, "the prefix cannot be given both as an argument and with --prefix")
+}
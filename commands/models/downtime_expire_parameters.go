@@ -0,0 +1,51 @@
+package models
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// DowntimeExpireParameters represents the parameters required to end the downtimes that have passed their expiry.
+type DowntimeExpireParameters struct {
+	StreamClass string          // The optional stream class filter.
+	DryRun      DryRunStrategy  // Whether to only print or server-side validate the changes instead of persisting them.
+	Output      string          // The output format of the resumed streams, see NewOutputFormat.
+	Audit       AuditParameters // The audit information recorded on the resumed streams.
+	Queue       QueueParameters // The settings of the processing of the stream list.
+}
+
+// NewDowntimeExpireParameters creates a new instance of DowntimeExpireParameters based on the provided command.
+func NewDowntimeExpireParameters(cmd *cobra.Command, configFlags *genericclioptions.ConfigFlags) (*DowntimeExpireParameters, error) { // coverage-ignore (tested in integration tests)
+	streamClass, err := cmd.Flags().GetString("stream-class")
+	if err != nil {
+		return nil, err
+	}
+
+	dryRun, err := NewDryRunStrategy(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := NewOutputFormat(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	audit, err := NewAuditParameters(cmd, configFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	queue, err := NewQueueParameters(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DowntimeExpireParameters{
+		StreamClass: streamClass,
+		DryRun:      dryRun,
+		Output:      output,
+		Audit:       audit,
+		Queue:       queue,
+	}, nil
+}
@@ -2,18 +2,53 @@ package models
 
 import (
 	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
 // DowntimeStopParameters represents the parameters required to perform a stop operation for a stream.
 type DowntimeStopParameters struct {
-	StreamClass string // The class of the stream to stop.
-	DowntimeKey string // The unique identifier of the downtime to declare.
+	StreamClass string          // The class of the stream to stop. If empty, streams of all stream classes are resumed.
+	DowntimeKey string          // The unique identifier of the downtime to declare.
+	DryRun      DryRunStrategy  // Whether to only print or server-side validate the changes instead of persisting them.
+	Output      string          // The output format of the modified streams, see NewOutputFormat.
+	Audit       AuditParameters // The audit information recorded on the modified streams.
+	Queue       QueueParameters // The settings of the processing of the stream list.
 }
 
 // NewDowntimeStopParameters creates a new instance of StopParameters based on the provided command and arguments.
-func NewDowntimeStopParameters(_ *cobra.Command, args []string) (*DowntimeStopParameters, error) { // coverage-ignore (tested in integration tests)
+func NewDowntimeStopParameters(cmd *cobra.Command, args []string, configFlags *genericclioptions.ConfigFlags) (*DowntimeStopParameters, error) { // coverage-ignore (tested in integration tests)
+	dryRun, err := NewDryRunStrategy(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := NewOutputFormat(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	audit, err := NewAuditParameters(cmd, configFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	queue, err := NewQueueParameters(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	// stop [<stream-class>] <key>
+	streamClass := ""
+	if len(args) == 2 {
+		streamClass = args[0]
+	}
+
 	return &DowntimeStopParameters{
-		StreamClass: args[0],
-		DowntimeKey: args[1],
+		StreamClass: streamClass,
+		DowntimeKey: args[len(args)-1],
+		DryRun:      dryRun,
+		Output:      output,
+		Audit:       audit,
+		Queue:       queue,
 	}, nil
 }
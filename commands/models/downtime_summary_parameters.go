@@ -7,6 +7,7 @@ import (
 // DowntimeSummaryParameters represents the parameters required to perform a list operation for active downtimes.
 type DowntimeSummaryParameters struct {
 	StreamClass string // The optional stream class filter
+	Output      string // The output format, see NewOutputFormat
 }
 
 // NewDowntimeSummaryParameters creates a new instance of StopParameters based on the provided command and arguments.
@@ -15,5 +16,11 @@ func NewDowntimeSummaryParameters(cmd *cobra.Command) (*DowntimeSummaryParameter
 	if err != nil {
 		return nil, err
 	}
-	return &DowntimeSummaryParameters{StreamClass: streamClass}, nil
+
+	output, err := NewOutputFormat(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DowntimeSummaryParameters{StreamClass: streamClass, Output: output}, nil
 }
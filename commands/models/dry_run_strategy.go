@@ -0,0 +1,52 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// DryRunStrategy defines whether a mutating command persists its changes, only prints them or submits them
+// to the server without persisting.
+type DryRunStrategy string
+
+const (
+	// DryRunNone means that the changes are persisted.
+	DryRunNone DryRunStrategy = "none"
+
+	// DryRunClient means that the changes are computed and printed without sending them to the server.
+	DryRunClient DryRunStrategy = "client"
+
+	// DryRunServer means that the changes are sent to the server with the dry run flag, so admission and schema
+	// validation are exercised without persisting the changes.
+	DryRunServer DryRunStrategy = "server"
+)
+
+// NewDryRunStrategy reads the dry run strategy from the --dry-run flag of the provided command.
+func NewDryRunStrategy(cmd *cobra.Command) (DryRunStrategy, error) { // coverage-ignore (tested in integration tests)
+	value, err := cmd.Flags().GetString("dry-run")
+	if err != nil {
+		return DryRunNone, err
+	}
+
+	switch strategy := DryRunStrategy(value); strategy {
+	case "", DryRunNone:
+		return DryRunNone, nil
+	case DryRunClient, DryRunServer:
+		return strategy, nil
+	default:
+		return DryRunNone, fmt.Errorf("invalid --dry-run value %q, must be one of none, client or server", value)
+	}
+}
+
+// Operation returns the operation name to print for a modified object, marked with the dry run strategy if any.
+func (d DryRunStrategy) Operation(operation string) string {
+	switch d {
+	case DryRunClient:
+		return operation + " (dry run)"
+	case DryRunServer:
+		return operation + " (server dry run)"
+	default:
+		return operation
+	}
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// daysPattern matches a duration with a leading number of days, e.g. 7d or 1d12h.
+var daysPattern = regexp.MustCompile(`^(\d+)d(.*)$`)
+
+// ParseDuration parses a duration like time.ParseDuration, and additionally accepts a leading number of days, e.g. 7d
+// or 1d12h, as retention periods and downtimes are usually given in days.
+func ParseDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, fmt.Errorf("invalid duration %q, use a number of days like 7d or a duration like 12h", value)
+	}
+
+	var days time.Duration
+	rest := value
+	if match := daysPattern.FindStringSubmatch(value); match != nil {
+		count, err := strconv.Atoi(match[1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", value, err)
+		}
+		days = time.Duration(count) * 24 * time.Hour
+		rest = match[2]
+	}
+	if rest == "" {
+		return days, nil
+	}
+
+	duration, err := time.ParseDuration(rest)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q, use a number of days like 7d or a duration like 12h: %w", value, err)
+	}
+	return days + duration, nil
+}
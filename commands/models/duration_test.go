@@ -0,0 +1,28 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseDuration(t *testing.T) {
+	for value, expected := range map[string]time.Duration{
+		"7d":    7 * 24 * time.Hour,
+		"1d12h": 36 * time.Hour,
+		"90m":   90 * time.Minute,
+		"0d":    0,
+	} {
+		duration, err := ParseDuration(value)
+		require.NoError(t, err, value)
+		require.Equal(t, expected, duration, value)
+	}
+}
+
+func Test_ParseDuration_Invalid(t *testing.T) {
+	for _, value := range []string{"", "d", "7days", "-1d"} {
+		_, err := ParseDuration(value)
+		require.Error(t, err, value)
+	}
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"github.com/sneaksAndData/kubectl-plugin-arcane/logging"
+	"github.com/spf13/cobra"
+)
+
+// NewOutputFormat reads the output format from the --output flag of the provided command and validates it,
+// so an unsupported format is reported before any object is modified.
+func NewOutputFormat(cmd *cobra.Command) (string, error) { // coverage-ignore (tested in integration tests)
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return "", err
+	}
+
+	_, err = logging.NewPrinter(output, "")
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
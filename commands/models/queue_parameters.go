@@ -0,0 +1,36 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// QueueParameters represents the settings of the commands that modify a list of streams one by one.
+type QueueParameters struct {
+	MaxAttempts int // The number of times a stream is processed before it is reported as failed.
+	Concurrency int // The number of streams processed at the same time.
+}
+
+// NewQueueParameters reads the queue settings from the --max-attempts and --concurrency flags of the provided command.
+func NewQueueParameters(cmd *cobra.Command) (QueueParameters, error) { // coverage-ignore (tested in integration tests)
+	maxAttempts, err := cmd.Flags().GetInt("max-attempts")
+	if err != nil {
+		return QueueParameters{}, err
+	}
+
+	if maxAttempts < 1 {
+		return QueueParameters{}, fmt.Errorf("--max-attempts must be at least 1")
+	}
+
+	concurrency, err := cmd.Flags().GetInt("concurrency")
+	if err != nil {
+		return QueueParameters{}, err
+	}
+
+	if concurrency < 1 {
+		return QueueParameters{}, fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	return QueueParameters{MaxAttempts: maxAttempts, Concurrency: concurrency}, nil
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// RebuildParameters represents the parameters required to stop a stream, backfill it and start it again.
+type RebuildParameters struct {
+	StreamClass   string          // The class of the stream to rebuild.
+	StreamId      string          // The unique identifier of the stream to rebuild.
+	Namespace     string          // The namespace in which the stream is located.
+	Timeout       time.Duration   // The maximum time to wait for the backfill to complete. Zero means no timeout.
+	StreamTimeout time.Duration   // The maximum time to wait for the stream to stop and start and for a rollback.
+	Output        string          // The output format of the modified stream and backfill request, see NewOutputFormat.
+	Audit         AuditParameters // The audit information recorded on the modified stream and backfill request.
+}
+
+// NewRebuildParameters creates a new instance of RebuildParameters based on the provided command and arguments.
+func NewRebuildParameters(cmd *cobra.Command, args []string, configFlags *genericclioptions.ConfigFlags) (*RebuildParameters, error) { // coverage-ignore (tested in integration tests)
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return nil, err
+	}
+
+	streamTimeout, err := cmd.Flags().GetDuration("stream-timeout")
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := NewOutputFormat(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	audit, err := NewAuditParameters(cmd, configFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, _, err := configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return nil, err
+	}
+
+	return &RebuildParameters{
+		StreamClass:   args[0],
+		StreamId:      args[1],
+		Namespace:     namespace,
+		Timeout:       timeout,
+		StreamTimeout: streamTimeout,
+		Output:        output,
+		Audit:         audit,
+	}, nil
+}
+
+// ToStopParameters returns the parameters of the step that stops the stream, waiting for it to be suspended if wait is set.
+func (p RebuildParameters) ToStopParameters(wait bool) *StopParameters {
+	return &StopParameters{
+		StreamClass: p.StreamClass,
+		StreamId:    p.StreamId,
+		Namespace:   p.Namespace,
+		Wait:        wait,
+		Timeout:     p.StreamTimeout,
+		DryRun:      DryRunNone,
+		Output:      p.Output,
+		Audit:       p.Audit,
+	}
+}
+
+// ToBackfillParameters returns the parameters of the step that backfills the stream and waits for the backfill to complete.
+func (p RebuildParameters) ToBackfillParameters() *BackfillParameters {
+	return &BackfillParameters{
+		StreamClass: p.StreamClass,
+		StreamId:    p.StreamId,
+		Namespace:   p.Namespace,
+		Wait:        true,
+		Timeout:     p.Timeout,
+		DryRun:      DryRunNone,
+		Output:      p.Output,
+		Audit:       p.Audit,
+	}
+}
+
+// ToBackfillCancelParameters returns the parameters that cancel the backfill of the stream if the rebuild is rolled back.
+func (p RebuildParameters) ToBackfillCancelParameters() *BackfillCancelParameters {
+	return &BackfillCancelParameters{
+		StreamClass: p.StreamClass,
+		StreamId:    p.StreamId,
+		Namespace:   p.Namespace,
+		Wait:        true,
+		Timeout:     p.StreamTimeout,
+		DryRun:      DryRunNone,
+		Output:      p.Output,
+	}
+}
+
+// ToStartParameters returns the parameters of the step that resumes the stream, waiting for it to run if wait is set.
+func (p RebuildParameters) ToStartParameters(wait bool) *StartParameters {
+	return &StartParameters{
+		StreamClass: p.StreamClass,
+		StreamId:    p.StreamId,
+		Namespace:   p.Namespace,
+		Wait:        wait,
+		Timeout:     p.StreamTimeout,
+		DryRun:      DryRunNone,
+		Output:      p.Output,
+		Audit:       p.Audit,
+	}
+}
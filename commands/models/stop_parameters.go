@@ -1,23 +1,81 @@
 package models
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
 // StopParameters represents the parameters required to perform a stop operation for a stream.
 type StopParameters struct {
-	StreamClass string // The class of the stream to stop.
-	StreamId    string // The unique identifier of the stream to stop.
-	Namespace   string // The unique identifier of the stream to stop.
+	StreamClass string          // The class of the stream to stop.
+	StreamId    string          // The unique identifier of the stream to stop.
+	Namespace   string          // The unique identifier of the stream to stop.
+	Wait        bool            // Whether to wait for the stream to reach the target phase before returning.
+	Timeout     time.Duration   // The maximum time to wait for the stream to reach the target phase.
+	DryRun      DryRunStrategy  // Whether to only print or server-side validate the changes instead of persisting them.
+	Output      string          // The output format of the modified streams, see NewOutputFormat.
+	Audit       AuditParameters // The audit information recorded on the modified streams.
+	Queue       QueueParameters // The settings of the processing of the stream list.
+
+	// Selection is the set of streams to modify in bulk. If nil, only the stream identified by StreamId is modified.
+	Selection *StreamSelection
 }
 
 // NewStopParameters creates a new instance of StopParameters based on the provided command and arguments.
-func NewStopParameters(_ *cobra.Command, args []string, configFlags *genericclioptions.ConfigFlags) (*StopParameters, error) { // coverage-ignore (tested in integration tests)
+func NewStopParameters(cmd *cobra.Command, args []string, configFlags *genericclioptions.ConfigFlags) (*StopParameters, error) { // coverage-ignore (tested in integration tests)
 	namespace, _, err := configFlags.ToRawKubeConfigLoader().Namespace()
 	if err != nil {
 		return nil, err
 	}
 
-	return &StopParameters{StreamClass: args[0], StreamId: args[1], Namespace: namespace}, nil
+	wait, err := cmd.Flags().GetBool("wait")
+	if err != nil {
+		return nil, err
+	}
+
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return nil, err
+	}
+
+	dryRun, err := NewDryRunStrategy(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	if wait && dryRun != DryRunNone {
+		return nil, fmt.Errorf("--wait cannot be combined with --dry-run")
+	}
+
+	output, err := NewOutputFormat(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	audit, err := NewAuditParameters(cmd, configFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	queue, err := NewQueueParameters(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	selection, err := NewStreamSelection(cmd, args[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	if selection != nil {
+		if wait {
+			return nil, fmt.Errorf("--wait is only supported for a single stream")
+		}
+		return &StopParameters{StreamClass: args[0], Namespace: namespace, Selection: selection, DryRun: dryRun, Output: output, Audit: audit, Queue: queue}, nil
+	}
+
+	return &StopParameters{StreamClass: args[0], StreamId: args[1], Namespace: namespace, Wait: wait, Timeout: timeout, DryRun: dryRun, Output: output, Audit: audit, Queue: queue}, nil
 }
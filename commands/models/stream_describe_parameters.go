@@ -0,0 +1,29 @@
+package models
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// StreamDescribeParameters represents the parameters required to describe a stream.
+type StreamDescribeParameters struct {
+	StreamClass string // The class of the stream to describe.
+	StreamId    string // The unique identifier of the stream to describe.
+	Namespace   string // The namespace in which the stream is located.
+	Output      string // The output format. If empty, a human-readable description is printed.
+}
+
+// NewStreamDescribeParameters creates a new instance of StreamDescribeParameters based on the provided command and arguments.
+func NewStreamDescribeParameters(cmd *cobra.Command, args []string, configFlags *genericclioptions.ConfigFlags) (*StreamDescribeParameters, error) { // coverage-ignore (tested in integration tests)
+	output, err := NewOutputFormat(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, _, err := configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return nil, err
+	}
+
+	return &StreamDescribeParameters{StreamClass: args[0], StreamId: args[1], Namespace: namespace, Output: output}, nil
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// StreamListParameters represents the parameters required to list streams in the cluster.
+type StreamListParameters struct {
+	StreamClass string // The optional stream class filter.
+	Namespace   string // The namespace to list streams in. If empty, streams from all namespaces are listed.
+	Output      string // The output format, see NewOutputFormat.
+}
+
+// NewStreamListParameters creates a new instance of StreamListParameters based on the provided command and arguments.
+func NewStreamListParameters(cmd *cobra.Command, configFlags *genericclioptions.ConfigFlags) (*StreamListParameters, error) { // coverage-ignore (tested in integration tests)
+	streamClass, err := cmd.Flags().GetString("stream-class")
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := NewOutputFormat(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	allNamespaces, err := cmd.Flags().GetBool("all-namespaces")
+	if err != nil {
+		return nil, err
+	}
+
+	if allNamespaces {
+		return &StreamListParameters{StreamClass: streamClass, Output: output}, nil
+	}
+
+	namespace, _, err := configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return nil, err
+	}
+
+	return &StreamListParameters{StreamClass: streamClass, Namespace: namespace, Output: output}, nil
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// StreamSelection represents a set of streams of a single stream class selected for a bulk operation.
+type StreamSelection struct {
+	StreamIds     []string // The explicit list of stream identifiers to select.
+	Prefix        string   // The name prefix of the streams to select.
+	LabelSelector string   // The label selector applied on the server side when listing the streams.
+	All           bool     // Whether to select all streams of the stream class.
+}
+
+// NewStreamSelection creates a new instance of StreamSelection based on the provided command flags and stream identifiers.
+// It returns nil if exactly one stream identifier is provided and no selection flags are set, which means that the
+// command should operate on a single stream.
+func NewStreamSelection(cmd *cobra.Command, streamIds []string) (*StreamSelection, error) { // coverage-ignore (tested in integration tests)
+	selector, err := cmd.Flags().GetString("selector")
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, err := cmd.Flags().GetString("prefix")
+	if err != nil {
+		return nil, err
+	}
+
+	fromFile, err := cmd.Flags().GetString("from-file")
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(streamIds) == 1 && selector == "" && prefix == "" && fromFile == "" && !all {
+		return nil, nil
+	}
+
+	if len(streamIds) == 0 && selector == "" && prefix == "" && fromFile == "" && !all {
+		return nil, fmt.Errorf("specify stream ids, --prefix, --selector, --from-file or --all")
+	}
+
+	if all && (len(streamIds) > 0 || prefix != "" || fromFile != "") {
+		return nil, fmt.Errorf("--all cannot be combined with stream ids, --prefix or --from-file")
+	}
+
+	if prefix != "" && (len(streamIds) > 0 || fromFile != "") {
+		return nil, fmt.Errorf("--prefix cannot be combined with stream ids or --from-file")
+	}
+
+	if fromFile != "" {
+		if len(streamIds) > 0 {
+			return nil, fmt.Errorf("--from-file cannot be combined with stream ids")
+		}
+		streamIds, err = readStreamIds(cmd.InOrStdin(), fromFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &StreamSelection{
+		StreamIds:     streamIds,
+		Prefix:        prefix,
+		LabelSelector: selector,
+		All:           all,
+	}, nil
+}
+
+// readStreamIds reads the stream identifiers listed in the file, one per line, skipping empty lines and comments
+// starting with '#'. The path '-' reads the list from stdin.
+func readStreamIds(stdin io.Reader, path string) ([]string, error) {
+	reader := stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("error opening stream list: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		reader = file
+	}
+
+	var streamIds []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		streamIds = append(streamIds, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading stream list: %w", err)
+	}
+
+	if len(streamIds) == 0 {
+		return nil, fmt.Errorf("no stream ids found in %s", path)
+	}
+	return streamIds, nil
+}
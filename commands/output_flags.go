@@ -0,0 +1,13 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/sneaksAndData/kubectl-plugin-arcane/logging"
+	"github.com/spf13/cobra"
+)
+
+// addOutputFlag adds the -o/--output flag supported by all commands, see models.NewOutputFormat.
+func addOutputFlag(cmd *cobra.Command) { // coverage-ignore (trivial)
+	cmd.Flags().StringP("output", "o", "", "Output format. One of: ("+strings.Join(logging.OutputFormats, ", ")+").")
+}
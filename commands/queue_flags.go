@@ -0,0 +1,9 @@
+package commands
+
+import "github.com/spf13/cobra"
+
+// addQueueFlags adds the flags of the commands that modify a list of streams one by one, see models.NewQueueParameters.
+func addQueueFlags(cmd *cobra.Command) { // coverage-ignore (trivial)
+	cmd.Flags().Int("max-attempts", 5, "The number of times a stream is processed before it is reported as failed, e.g. when the update is rejected")
+	cmd.Flags().Int("concurrency", 1, "The number of streams processed at the same time, the requests to the API server are limited by --qps and --burst")
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/internal"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
@@ -11,16 +12,18 @@ type RootCommand interface {
 	internal.GenericCommand
 }
 
-// NewRootCommand creates a new RootCommand with the provided StreamCommand and DowntimeCommand as subcommands. It also adds the necessary flags for Kubernetes configuration.
-func NewRootCommand(configFlags *genericclioptions.ConfigFlags, streamCommand StreamCommand, downtimeCommand DowntimeCommand, version VersionCommand) RootCommand { // coverage-ignore (trivial)
+// NewRootCommand creates a new RootCommand with the provided StreamCommand, DowntimeCommand and BackfillCommand as subcommands. It also adds the necessary flags for Kubernetes configuration and the API server rate limits.
+func NewRootCommand(configFlags *genericclioptions.ConfigFlags, clientParameters *models.ClientParameters, streamCommand StreamCommand, downtimeCommand DowntimeCommand, backfillCommand BackfillCommand, version VersionCommand) RootCommand { // coverage-ignore (trivial)
 	rootCommand := &cobra.Command{
 		Use: "kubectl-arcane",
 	}
 	rootCommand.AddCommand(streamCommand.GetCommand())
 	rootCommand.AddCommand(downtimeCommand.GetCommand())
+	rootCommand.AddCommand(backfillCommand.GetCommand())
 	rootCommand.AddCommand(version.GetCommand())
 
 	configFlags.AddFlags(rootCommand.PersistentFlags())
+	addClientFlags(rootCommand, clientParameters)
 
 	return internal.NewGenericCommand(rootCommand)
 }
@@ -5,20 +5,23 @@ import (
 	"github.com/spf13/cobra"
 )
 
-// StreamCommand is the interface for the stream command, which has subcommands for starting, stopping and backfilling streams.
+// StreamCommand is the interface for the stream command, which has subcommands for listing, describing, starting, stopping, backfilling and rebuilding streams.
 type StreamCommand interface {
 	internal.GenericCommand
 }
 
-// NewStreamCommand creates a new instance of the StreamCommand, which includes the start, stop and backfill subcommands.
-func NewStreamCommand(start StreamStart, stop StreamStop, backfill StreamBackfill) StreamCommand { // coverage-ignore (trivial)
+// NewStreamCommand creates a new instance of the StreamCommand, which includes the list, describe, start, stop, backfill and rebuild subcommands.
+func NewStreamCommand(start StreamStart, stop StreamStop, backfill StreamBackfill, list StreamList, describe StreamDescribe, rebuild StreamRebuild) StreamCommand { // coverage-ignore (trivial)
 	cmd := cobra.Command{
 		Use:   "stream",
-		Short: "Interact with individual streams, including starting, stopping and backfilling",
+		Short: "Interact with individual streams, including listing, describing, starting, stopping, backfilling and rebuilding",
 	}
 	cmd.AddCommand(start.GetCommand())
 	cmd.AddCommand(stop.GetCommand())
 	cmd.AddCommand(backfill.GetCommand())
+	cmd.AddCommand(list.GetCommand())
+	cmd.AddCommand(describe.GetCommand())
+	cmd.AddCommand(rebuild.GetCommand())
 
 	return internal.NewGenericCommand(&cmd)
 }
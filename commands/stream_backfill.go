@@ -14,11 +14,11 @@ type StreamBackfill interface {
 }
 
 // NewStreamBackfill creates a new instance of the StreamBackfill command, which runs a stream backfill operation.
-func NewStreamBackfill(backfillService interfaces.BackfillService, configFlags *genericclioptions.ConfigFlags) StreamBackfill { // coverage-ignore (trivial)
+func NewStreamBackfill(backfillService interfaces.BackfillService, configFlags *genericclioptions.ConfigFlags, cancel StreamBackfillCancel) StreamBackfill { // coverage-ignore (trivial)
 	cmd := cobra.Command{
-		Use:   "backfill <stream-class> <stream-id> [--wait]",
-		Args:  cobra.ExactArgs(2),
-		Short: "Run a stream in backfill mode",
+		Use:   "backfill <stream-class> [<stream-id>...] [--prefix <prefix>] [--selector <selector>] [--from-file <file>] [--all] [--max-in-flight <n>] [--wait] [--replace] [--timeout <duration>]",
+		Args:  cobra.MinimumNArgs(1),
+		Short: "Run a stream or a list of streams in backfill mode",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			parameters, err := models.NewBackfillParameters(cmd, args, configFlags)
 			if err != nil {
@@ -27,6 +27,14 @@ func NewStreamBackfill(backfillService interfaces.BackfillService, configFlags *
 			return backfillService.Backfill(cmd.Context(), parameters)
 		},
 	}
-	cmd.Flags().Bool("wait", false, "Wait for backfill command to complete")
+	cmd.Flags().Bool("wait", false, "Wait for backfill command to complete. Exits with code 2 if the --timeout passes, 3 if interrupted and 4 if a backfill failed")
+	cmd.Flags().Bool("replace", false, "Cancel the active backfill request of the stream, if any, and create a new one")
+	cmd.Flags().Duration("timeout", 0, "The maximum time to wait for the backfill to complete with --wait, and for the backfill job of the replaced backfill request to terminate with --replace. Zero means no timeout")
+	cmd.Flags().Int("max-in-flight", 0, "The maximum number of backfills of a list of streams running at the same time, waiting for their completion before starting the next ones. Zero means no limit")
+	addStreamSelectionFlags(&cmd)
+	addDryRunFlag(&cmd)
+	addOutputFlag(&cmd)
+	addAuditFlags(&cmd)
+	cmd.AddCommand(cancel.GetCommand())
 	return internal.NewGenericCommand(&cmd)
 }
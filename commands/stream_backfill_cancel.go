@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/internal"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// StreamBackfillCancel is a command that cancels the active backfill request of a stream.
+type StreamBackfillCancel interface {
+	internal.GenericCommand
+}
+
+// NewStreamBackfillCancel creates a new instance of the StreamBackfillCancel command, which deletes the active backfill request of a stream.
+func NewStreamBackfillCancel(backfillService interfaces.BackfillService, configFlags *genericclioptions.ConfigFlags) StreamBackfillCancel { // coverage-ignore (trivial)
+	cmd := cobra.Command{
+		Use:   "cancel <stream-class> <stream-id> [--wait] [--timeout <duration>]",
+		Args:  cobra.ExactArgs(2),
+		Short: "Cancel the active backfill of a stream",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			parameters, err := models.NewBackfillCancelParameters(cmd, args, configFlags)
+			if err != nil {
+				return err
+			}
+			return backfillService.Cancel(cmd.Context(), parameters)
+		},
+	}
+	cmd.Flags().Bool("wait", false, "Wait for the backfill job to terminate")
+	cmd.Flags().Duration("timeout", 5*time.Minute, "The maximum time to wait for the backfill job when --wait is set")
+	addDryRunFlag(&cmd)
+	addOutputFlag(&cmd)
+	return internal.NewGenericCommand(&cmd)
+}
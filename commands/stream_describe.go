@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/internal"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/logging"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// StreamDescribe is a command that shows the details of a stream and its related resources.
+type StreamDescribe interface {
+	internal.GenericCommand
+}
+
+// NewStreamDescribe creates a new instance of the StreamDescribe command, which shows the stream definition, backfill requests, jobs, pods and events of a stream.
+func NewStreamDescribe(streamService interfaces.StreamService, configFlags *genericclioptions.ConfigFlags) StreamDescribe { // coverage-ignore (tested by integration tests)
+	cmd := cobra.Command{
+		Use:   "describe <stream-class> <stream-id>",
+		Args:  cobra.ExactArgs(2),
+		Short: "Show details of a stream, including its backfill requests, jobs, pods and recent events",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			parameters, err := models.NewStreamDescribeParameters(cmd, args, configFlags)
+			if err != nil {
+				return err
+			}
+
+			description, err := streamService.Describe(cmd.Context(), parameters)
+			if err != nil {
+				return err
+			}
+
+			if parameters.Output == "" {
+				return description.Print(os.Stdout)
+			}
+
+			printer, err := logging.NewPrinter(parameters.Output, "")
+			if err != nil {
+				return err
+			}
+			return printer.PrintObj(description.Raw(), os.Stdout)
+		},
+	}
+	addOutputFlag(&cmd)
+	return internal.NewGenericCommand(&cmd)
+}
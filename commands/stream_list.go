@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/internal"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/logging"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// StreamList is a command that lists streams across all stream classes.
+type StreamList interface {
+	internal.GenericCommand
+}
+
+// NewStreamList creates a new instance of the StreamList command, which lists streams across all stream classes.
+func NewStreamList(streamService interfaces.StreamService, configFlags *genericclioptions.ConfigFlags) StreamList { // coverage-ignore (tested by integration tests)
+	cmd := cobra.Command{
+		Use:   "list [--stream-class <stream-class>] [--all-namespaces]",
+		Args:  cobra.NoArgs,
+		Short: "List streams with their phase and downtime information, optionally filtered by stream class",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			parameters, err := models.NewStreamListParameters(cmd, configFlags)
+			if err != nil {
+				return err
+			}
+
+			inventory, err := streamService.List(cmd.Context(), parameters)
+			if err != nil {
+				return err
+			}
+
+			return logging.PrintList(parameters.Output, inventory.Table(), inventory.Objects(), os.Stdout)
+		},
+	}
+
+	cmd.Flags().String("stream-class", "", "Filter by stream class")
+	cmd.Flags().BoolP("all-namespaces", "A", false, "List streams across all namespaces")
+	addOutputFlag(&cmd)
+
+	return internal.NewGenericCommand(&cmd)
+}
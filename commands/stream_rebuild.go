@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/internal"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// defaultRebuildTimeout is the default maximum time to wait for the backfill of a rebuild to complete.
+const defaultRebuildTimeout = 6 * time.Hour
+
+// StreamRebuild is a command that stops a stream, backfills it and starts it again.
+type StreamRebuild interface {
+	internal.GenericCommand
+}
+
+// NewStreamRebuild creates a new instance of the StreamRebuild command, which stops a stream, backfills it and starts
+// it again, and returns the stream to its original suspension state if a step fails.
+func NewStreamRebuild(rebuildService interfaces.StreamRebuildService, configFlags *genericclioptions.ConfigFlags) StreamRebuild { // coverage-ignore (trivial)
+	cmd := cobra.Command{
+		Use:   "rebuild <stream-class> <stream-id> [--timeout <duration>] [--stream-timeout <duration>]",
+		Args:  cobra.ExactArgs(2),
+		Short: "Stop a stream, backfill it and start it again",
+		Long: `Stop a stream, backfill it and wait for the backfill to complete, then start the stream.
+
+The operator does not backfill suspended streams, so the stream is resumed right before the backfill request is
+created and the operator keeps its streaming job stopped until the backfill completes. If a step fails, the stream
+is returned to its suspension state before the rebuild, and the backfill of a stream that was suspended is cancelled.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			parameters, err := models.NewRebuildParameters(cmd, args, configFlags)
+			if err != nil {
+				return err
+			}
+			return rebuildService.Rebuild(cmd.Context(), parameters)
+		},
+	}
+	cmd.Flags().Duration("timeout", defaultRebuildTimeout, "The maximum time to wait for the backfill to complete. Zero means no timeout")
+	cmd.Flags().Duration("stream-timeout", 5*time.Minute, "The maximum time to wait for the stream to stop and start and for a rollback")
+	addOutputFlag(&cmd)
+	addAuditFlags(&cmd)
+	return internal.NewGenericCommand(&cmd)
+}
@@ -0,0 +1,11 @@
+package commands
+
+import "github.com/spf13/cobra"
+
+// addStreamSelectionFlags adds the flags used to select a list of streams for a bulk operation, see models.NewStreamSelection.
+func addStreamSelectionFlags(cmd *cobra.Command) { // coverage-ignore (trivial)
+	cmd.Flags().StringP("selector", "l", "", "Select streams by label selector, supports '=', '==', '!=', 'in' and 'notin'")
+	cmd.Flags().String("prefix", "", "Select streams with names starting with the given prefix")
+	cmd.Flags().String("from-file", "", "Select the streams listed in the file, one stream id per line, use '-' to read the list from stdin")
+	cmd.Flags().Bool("all", false, "Select all streams of the stream class")
+}
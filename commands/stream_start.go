@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"time"
+
 	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/internal"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
@@ -16,9 +18,9 @@ type StreamStart interface {
 // NewStreamStart creates a new instance of the StreamStart command, which runs a stream start operation.
 func NewStreamStart(streamService interfaces.StreamService, configFlags *genericclioptions.ConfigFlags) StreamStart { // coverage-ignore (trivial)
 	cmd := cobra.Command{
-		Use:   "start <stream-class> <stream-id>",
-		Args:  cobra.ExactArgs(2),
-		Short: "Start a stream",
+		Use:   "start <stream-class> [<stream-id>...] [--prefix <prefix>] [--selector <selector>] [--from-file <file>] [--all] [--wait] [--timeout <duration>]",
+		Args:  cobra.MinimumNArgs(1),
+		Short: "Start a stream or a list of streams",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			startParameters, err := models.NewStartParameters(cmd, args, configFlags)
 			if err != nil {
@@ -27,5 +29,12 @@ func NewStreamStart(streamService interfaces.StreamService, configFlags *generic
 			return streamService.Start(cmd.Context(), startParameters)
 		},
 	}
+	addStreamSelectionFlags(&cmd)
+	addDryRunFlag(&cmd)
+	addOutputFlag(&cmd)
+	addAuditFlags(&cmd)
+	addQueueFlags(&cmd)
+	cmd.Flags().Bool("wait", false, "Wait for the stream to reach the Running phase, only supported for a single stream")
+	cmd.Flags().Duration("timeout", 5*time.Minute, "The maximum time to wait for the stream when --wait is set")
 	return internal.NewGenericCommand(&cmd)
 }
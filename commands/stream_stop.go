@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"time"
+
 	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/internal"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
@@ -16,9 +18,9 @@ type StreamStop interface {
 // NewStreamStop creates a new instance of the StreamStop command, which runs a stream stop operation.
 func NewStreamStop(streamService interfaces.StreamService, configFlags *genericclioptions.ConfigFlags) StreamStop { // coverage-ignore (trivial)
 	cmd := cobra.Command{
-		Use:   "stop <stream-class> <stream-id>",
-		Args:  cobra.ExactArgs(2),
-		Short: "Stop a stream",
+		Use:   "stop <stream-class> [<stream-id>...] [--prefix <prefix>] [--selector <selector>] [--from-file <file>] [--all] [--wait] [--timeout <duration>]",
+		Args:  cobra.MinimumNArgs(1),
+		Short: "Stop a stream or a list of streams",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			stopParameters, err := models.NewStopParameters(cmd, args, configFlags)
 			if err != nil {
@@ -27,5 +29,12 @@ func NewStreamStop(streamService interfaces.StreamService, configFlags *genericc
 			return streamService.Stop(cmd.Context(), stopParameters)
 		},
 	}
+	addStreamSelectionFlags(&cmd)
+	addDryRunFlag(&cmd)
+	addOutputFlag(&cmd)
+	addAuditFlags(&cmd)
+	addQueueFlags(&cmd)
+	cmd.Flags().Bool("wait", false, "Wait for the stream to reach the Suspended phase and its streaming job to terminate, only supported for a single stream")
+	cmd.Flags().Duration("timeout", 5*time.Minute, "The maximum time to wait for the stream when --wait is set")
 	return internal.NewGenericCommand(&cmd)
 }
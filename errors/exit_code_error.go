@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"errors"
+)
+
+// Exit codes of the plugin, so scripts can tell why a waiting command did not complete.
+const (
+	// ExitCodeError is returned for any error without a more specific exit code.
+	ExitCodeError = 1
+
+	// ExitCodeTimeout is returned if the command did not complete within the --timeout.
+	ExitCodeTimeout = 2
+
+	// ExitCodeInterrupted is returned if the command was interrupted, e.g. by Ctrl+C.
+	ExitCodeInterrupted = 3
+
+	// ExitCodeFailed is returned if the command completed, but the operation it waited for failed in the cluster.
+	ExitCodeFailed = 4
+
+	// ExitCodePartialFailure is returned if a command modifying a list of streams could not modify some of them.
+	ExitCodePartialFailure = 5
+)
+
+// exitCodeError wraps an error with the exit code the plugin returns for it.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+// NewTimeoutError wraps the error with the ExitCodeTimeout exit code.
+func NewTimeoutError(err error) error {
+	return &exitCodeError{code: ExitCodeTimeout, err: err}
+}
+
+// NewInterruptedError wraps the error with the ExitCodeInterrupted exit code.
+func NewInterruptedError(err error) error {
+	return &exitCodeError{code: ExitCodeInterrupted, err: err}
+}
+
+// NewFailedError wraps the error with the ExitCodeFailed exit code.
+func NewFailedError(err error) error {
+	return &exitCodeError{code: ExitCodeFailed, err: err}
+}
+
+// NewPartialFailureError wraps the error with the ExitCodePartialFailure exit code.
+func NewPartialFailureError(err error) error {
+	return &exitCodeError{code: ExitCodePartialFailure, err: err}
+}
+
+// Error returns the message of the wrapped error.
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+// ExitCode returns the exit code for the error: 0 if there is no error, the code of the first wrapped error with an
+// exit code, or ExitCodeError otherwise.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *exitCodeError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	return ExitCodeError
+}
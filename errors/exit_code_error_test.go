@@ -0,0 +1,17 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExitCode(t *testing.T) {
+	require.Equal(t, 0, ExitCode(nil))
+	require.Equal(t, ExitCodeError, ExitCode(fmt.Errorf("failed")))
+	require.Equal(t, ExitCodeTimeout, ExitCode(fmt.Errorf("wrapped: %w", NewTimeoutError(fmt.Errorf("timed out")))))
+	require.Equal(t, ExitCodeInterrupted, ExitCode(NewInterruptedError(fmt.Errorf("interrupted"))))
+	require.Equal(t, ExitCodeFailed, ExitCode(NewFailedError(fmt.Errorf("backfill failed"))))
+	require.Equal(t, ExitCodePartialFailure, ExitCode(NewPartialFailureError(NewProcessingError(nil))))
+}
@@ -0,0 +1,26 @@
+package errors
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// NoActiveBackfillError is returned when a stream has no backfill request that is not completed yet.
+type NoActiveBackfillError struct {
+	StreamClass string
+	name        types.NamespacedName
+}
+
+// NewNoActiveBackfillError creates a new instance of NoActiveBackfillError with the provided stream class and namespaced name.
+func NewNoActiveBackfillError(streamClass string, name types.NamespacedName) *NoActiveBackfillError {
+	return &NoActiveBackfillError{
+		StreamClass: streamClass,
+		name:        name,
+	}
+}
+
+// Error returns a string representation of the NoActiveBackfillError.
+func (e *NoActiveBackfillError) Error() string {
+	return fmt.Sprintf("no active backfill request found for stream %s of stream class %s in namespace %s", e.name.Name, e.StreamClass, e.name.Namespace)
+}
@@ -0,0 +1,36 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ItemFailure is a stream that could not be processed, together with the number of attempts and the last error.
+type ItemFailure struct {
+	Name     types.NamespacedName
+	Attempts int
+	Err      error
+}
+
+// ProcessingError is returned when some streams of a bulk operation could not be processed after all attempts.
+type ProcessingError struct {
+	Failures []ItemFailure
+}
+
+// NewProcessingError creates a new instance of ProcessingError with the provided failed streams.
+func NewProcessingError(failures []ItemFailure) *ProcessingError {
+	return &ProcessingError{
+		Failures: failures,
+	}
+}
+
+// Error returns a string representation of the ProcessingError, listing each failed stream with its last error.
+func (e *ProcessingError) Error() string {
+	failures := make([]string, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		failures = append(failures, fmt.Sprintf("%s after %d attempts: %v", failure.Name, failure.Attempts, failure.Err))
+	}
+	return fmt.Sprintf("failed to process %d streams: %s", len(e.Failures), strings.Join(failures, "; "))
+}
@@ -0,0 +1,18 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_ProcessingError(t *testing.T) {
+	err := NewProcessingError([]ItemFailure{
+		{Name: types.NamespacedName{Namespace: "default", Name: "stream-a"}, Attempts: 5, Err: fmt.Errorf("admission webhook denied the request")},
+		{Name: types.NamespacedName{Namespace: "default", Name: "stream-b"}, Attempts: 1, Err: fmt.Errorf("no client")},
+	})
+	require.Equal(t, "failed to process 2 streams: default/stream-a after 5 attempts: admission webhook denied the request; default/stream-b after 1 attempts: no client", err.Error())
+	require.Equal(t, ExitCodeError, ExitCode(err))
+}
@@ -0,0 +1,19 @@
+package errors
+
+// SkippedError is returned by a processor for a stream that it intentionally did not modify, e.g. because the stream
+// belongs to a different downtime. Unlike other errors, it is not retried.
+type SkippedError struct {
+	Reason string
+}
+
+// NewSkippedError creates a new instance of SkippedError with the provided reason.
+func NewSkippedError(reason string) *SkippedError {
+	return &SkippedError{
+		Reason: reason,
+	}
+}
+
+// Error returns the reason the stream was skipped.
+func (e *SkippedError) Error() string {
+	return e.Reason
+}
@@ -0,0 +1,155 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+var _ printers.ResourcePrinter = (*CustomColumnsPrinter)(nil)
+
+// CustomColumn is a single column of the custom-columns output, consisting of a header and a JSONPath expression.
+type CustomColumn struct {
+	Header   string
+	template *jsonpath.JSONPath
+}
+
+// CustomColumnsPrinter prints objects as a table with user defined columns, similar to kubectl -o custom-columns.
+type CustomColumnsPrinter struct {
+	Columns []CustomColumn
+}
+
+// NewCustomColumnsPrinter creates a CustomColumnsPrinter from a specification like "NAME:.metadata.name,PHASE:.status.phase".
+func NewCustomColumnsPrinter(spec string) (*CustomColumnsPrinter, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, fmt.Errorf("custom-columns format specified but no custom columns given")
+	}
+
+	var columns []CustomColumn
+	for _, part := range strings.Split(spec, ",") {
+		header, expression, found := strings.Cut(part, ":")
+		if !found || header == "" || expression == "" {
+			return nil, fmt.Errorf("unexpected custom-columns spec: %s, expected <header>:<json-path-expr>", part)
+		}
+
+		template := jsonpath.New(header).AllowMissingKeys(true)
+		err := template.Parse(relaxedJSONPathExpression(expression))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing custom-columns expression %s: %w", expression, err)
+		}
+		columns = append(columns, CustomColumn{Header: header, template: template})
+	}
+
+	return &CustomColumnsPrinter{Columns: columns}, nil
+}
+
+// NewCustomColumnsPrinterFromFile creates a CustomColumnsPrinter from a file containing a line with the column headers
+// and a line with the corresponding JSONPath expressions, separated by whitespace.
+func NewCustomColumnsPrinterFromFile(path string) (*CustomColumnsPrinter, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading custom-columns file %s: %w", path, err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		return nil, fmt.Errorf("custom-columns file %s must contain exactly two lines: headers and expressions", path)
+	}
+
+	headers, expressions := strings.Fields(lines[0]), strings.Fields(lines[1])
+	if len(headers) != len(expressions) {
+		return nil, fmt.Errorf("custom-columns file %s has %d headers but %d expressions", path, len(headers), len(expressions))
+	}
+
+	parts := make([]string, 0, len(headers))
+	for i := range headers {
+		parts = append(parts, headers[i]+":"+expressions[i])
+	}
+	return NewCustomColumnsPrinter(strings.Join(parts, ","))
+}
+
+// PrintObj prints the object, or each item of the object if it is a list, as a row of the table.
+func (p *CustomColumnsPrinter) PrintObj(obj runtime.Object, w io.Writer) error {
+	out := tabwriter.NewWriter(w, 5, 8, 3, ' ', 0)
+
+	headers := make([]string, 0, len(p.Columns))
+	for _, column := range p.Columns {
+		headers = append(headers, column.Header)
+	}
+	_, err := fmt.Fprintln(out, strings.Join(headers, "\t"))
+	if err != nil {
+		return err
+	}
+
+	items := []runtime.Object{obj}
+	if meta.IsListType(obj) {
+		items, err = meta.ExtractList(obj)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, item := range items {
+		err = p.printRow(item, out)
+		if err != nil {
+			return err
+		}
+	}
+
+	return out.Flush()
+}
+
+func (p *CustomColumnsPrinter) printRow(obj runtime.Object, w io.Writer) error {
+	// Convert the object to its JSON representation, so the expressions use the same field names as the API
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	var content interface{}
+	err = json.Unmarshal(data, &content)
+	if err != nil {
+		return err
+	}
+
+	cells := make([]string, 0, len(p.Columns))
+	for _, column := range p.Columns {
+		results, err := column.template.FindResults(content)
+		if err != nil {
+			return fmt.Errorf("error evaluating custom column %s: %w", column.Header, err)
+		}
+
+		var values []string
+		for _, result := range results {
+			for _, value := range result {
+				values = append(values, fmt.Sprintf("%v", value.Interface()))
+			}
+		}
+		if len(values) == 0 {
+			cells = append(cells, "<none>")
+			continue
+		}
+		cells = append(cells, strings.Join(values, ","))
+	}
+
+	_, err = fmt.Fprintln(w, strings.Join(cells, "\t"))
+	return err
+}
+
+// relaxedJSONPathExpression wraps expressions like ".metadata.name" in braces, as kubectl does for custom columns.
+func relaxedJSONPathExpression(expression string) string {
+	if strings.HasPrefix(expression, "{") && strings.HasSuffix(expression, "}") {
+		return expression
+	}
+	if !strings.HasPrefix(expression, ".") {
+		expression = "." + expression
+	}
+	return "{" + expression + "}"
+}
@@ -0,0 +1,145 @@
+package logging
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_NewCustomColumnsPrinter(t *testing.T) {
+	for _, tc := range []struct {
+		spec    string
+		headers []string
+		err     string
+	}{
+		{spec: "NAME:.metadata.name", headers: []string{"NAME"}},
+		{spec: "NAME:.metadata.name,PHASE:.status.phase", headers: []string{"NAME", "PHASE"}},
+		{spec: "NAME:metadata.name", headers: []string{"NAME"}},
+		{spec: "NAME:{.metadata.name}", headers: []string{"NAME"}},
+		{spec: "", err: "no custom columns given"},
+		{spec: "  ", err: "no custom columns given"},
+		{spec: "NAME", err: "expected <header>:<json-path-expr>"},
+		{spec: ":.metadata.name", err: "expected <header>:<json-path-expr>"},
+		{spec: "NAME:", err: "expected <header>:<json-path-expr>"},
+		{spec: "NAME:.metadata.name,PHASE", err: "unexpected custom-columns spec: PHASE"},
+		{spec: "NAME:.metadata[", err: "error parsing custom-columns expression"},
+	} {
+		t.Run(tc.spec, func(t *testing.T) {
+			printer, err := NewCustomColumnsPrinter(tc.spec)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			headers := make([]string, 0, len(printer.Columns))
+			for _, column := range printer.Columns {
+				headers = append(headers, column.Header)
+			}
+			require.Equal(t, tc.headers, headers)
+		})
+	}
+}
+
+func Test_NewCustomColumnsPrinterFromFile(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		headers []string
+		err     string
+	}{
+		{name: "single column", content: "NAME\n.metadata.name\n", headers: []string{"NAME"}},
+		{name: "aligned columns", content: "NAME            PHASE\n.metadata.name  .status.phase\n", headers: []string{"NAME", "PHASE"}},
+		{name: "one line", content: "NAME PHASE\n", err: "must contain exactly two lines"},
+		{name: "three lines", content: "NAME\n.metadata.name\n.status.phase\n", err: "must contain exactly two lines"},
+		{name: "missing expression", content: "NAME PHASE\n.metadata.name\n", err: "has 2 headers but 1 expressions"},
+		{name: "invalid expression", content: "NAME\n.metadata[\n", err: "error parsing custom-columns expression"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "columns.txt")
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0o600))
+
+			printer, err := NewCustomColumnsPrinterFromFile(path)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			headers := make([]string, 0, len(printer.Columns))
+			for _, column := range printer.Columns {
+				headers = append(headers, column.Header)
+			}
+			require.Equal(t, tc.headers, headers)
+		})
+	}
+}
+
+func Test_NewCustomColumnsPrinterFromFile_Missing(t *testing.T) {
+	_, err := NewCustomColumnsPrinterFromFile(filepath.Join(t.TempDir(), "missing.txt"))
+
+	require.ErrorContains(t, err, "error reading custom-columns file")
+}
+
+func Test_RelaxedJSONPathExpression(t *testing.T) {
+	for expression, expected := range map[string]string{
+		".metadata.name":             "{.metadata.name}",
+		"metadata.name":              "{.metadata.name}",
+		"{.metadata.name}":           "{.metadata.name}",
+		".spec.items[*].name":        "{.spec.items[*].name}",
+		"{range .items[*]}{.a}{end}": "{range .items[*]}{.a}{end}",
+	} {
+		require.Equal(t, expected, relaxedJSONPathExpression(expression), expression)
+	}
+}
+
+func Test_CustomColumnsPrinter_Object(t *testing.T) {
+	// Arrange
+	printer, err := NewCustomColumnsPrinter("NAME:.metadata.name,PHASE:.status.phase,KEY:.metadata.labels.missing")
+	require.NoError(t, err)
+	buffer := &bytes.Buffer{}
+
+	// Act
+	err = printer.PrintObj(newTestObject("stream-a", "Running"), buffer)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "NAME       PHASE     KEY\nstream-a   Running   <none>\n", buffer.String())
+}
+
+func Test_CustomColumnsPrinter_MultipleValues(t *testing.T) {
+	// Arrange
+	printer, err := NewCustomColumnsPrinter("KEYS:.spec.keys[*]")
+	require.NoError(t, err)
+	object := newTestObject("stream-a", "Running")
+	require.NoError(t, unstructured.SetNestedStringSlice(object.Object, []string{"key-a", "key-b"}, "spec", "keys"))
+	buffer := &bytes.Buffer{}
+
+	// Act
+	err = printer.PrintObj(object, buffer)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "KEYS\nkey-a,key-b\n", buffer.String())
+}
+
+func Test_CustomColumnsPrinter_EmptyList(t *testing.T) {
+	// Arrange
+	printer, err := NewCustomColumnsPrinter("NAME:.metadata.name")
+	require.NoError(t, err)
+	list := &unstructured.UnstructuredList{}
+	list.SetAPIVersion("v1")
+	list.SetKind("List")
+	buffer := &bytes.Buffer{}
+
+	// Act
+	err = printer.PrintObj(list, buffer)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "NAME\n", buffer.String())
+}
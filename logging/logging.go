@@ -12,3 +12,20 @@ func LogError(object PrintableObject, operation string, cause error) { // covera
 		panic(err)
 	}
 }
+
+// LogProgress reports the progress of a long-running operation on stderr, so it does not mix with the command output.
+func LogProgress(object PrintableObject, message string) { // coverage-ignore
+	name := FormatName(object)
+	_, err := fmt.Fprintf(os.Stderr, "%s %s\n", name, message)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// LogStep reports the start of a step of a multi-step operation on stderr, so it does not mix with the command output.
+func LogStep(step int, steps int, message string) { // coverage-ignore
+	_, err := fmt.Fprintf(os.Stderr, "[%d/%d] %s\n", step, steps, message)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,119 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_NewPrinter_Json(t *testing.T) {
+	// Arrange
+	printer, err := NewPrinter("json", "started")
+	require.NoError(t, err)
+	buffer := &bytes.Buffer{}
+
+	// Act
+	err = printer.PrintObj(newTestObject("stream-a", "Running"), buffer)
+
+	// Assert
+	require.NoError(t, err)
+	require.Contains(t, buffer.String(), `"name": "stream-a"`)
+	require.NotContains(t, buffer.String(), "started")
+}
+
+func Test_NewPrinter_Name(t *testing.T) {
+	// Arrange
+	printer, err := NewPrinter("name", "started")
+	require.NoError(t, err)
+	buffer := &bytes.Buffer{}
+
+	// Act
+	err = printer.PrintObj(newTestObject("stream-a", "Running"), buffer)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "mockstreamdefinition.streaming.sneaksanddata.com/stream-a\n", buffer.String())
+}
+
+func Test_NewPrinter_JsonPath(t *testing.T) {
+	// Arrange
+	printer, err := NewPrinter("jsonpath={.status.phase}", "")
+	require.NoError(t, err)
+	buffer := &bytes.Buffer{}
+
+	// Act
+	err = printer.PrintObj(newTestObject("stream-a", "Running"), buffer)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "Running", buffer.String())
+}
+
+func Test_NewPrinter_Unsupported(t *testing.T) {
+	// Act
+	_, err := NewPrinter("xml", "")
+
+	// Assert
+	require.ErrorContains(t, err, "unsupported output format")
+}
+
+func Test_CustomColumnsPrinter_List(t *testing.T) {
+	// Arrange
+	printer, err := NewPrinter("custom-columns=NAME:.metadata.name,PHASE:.status.phase,KEY:.metadata.labels.missing", "")
+	require.NoError(t, err)
+	list := &unstructured.UnstructuredList{}
+	list.SetAPIVersion("v1")
+	list.SetKind("List")
+	list.Items = append(list.Items, *newTestObject("stream-a", "Running"), *newTestObject("stream-b", "Suspended"))
+	buffer := &bytes.Buffer{}
+
+	// Act
+	err = printer.PrintObj(list, buffer)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "NAME       PHASE       KEY\nstream-a   Running     <none>\nstream-b   Suspended   <none>\n", buffer.String())
+}
+
+func Test_CustomColumnsPrinter_InvalidSpec(t *testing.T) {
+	// Act
+	_, err := NewCustomColumnsPrinter("NAME")
+
+	// Assert
+	require.ErrorContains(t, err, "expected <header>:<json-path-expr>")
+}
+
+func Test_PrintList_Table(t *testing.T) {
+	// Arrange
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Kind", Type: "string", Priority: 1},
+		},
+		Rows: []metav1.TableRow{{Cells: []interface{}{"stream-a", "MockStreamDefinition"}}},
+	}
+	defaultBuffer, wideBuffer := &bytes.Buffer{}, &bytes.Buffer{}
+
+	// Act
+	errDefault := PrintList("", table, nil, defaultBuffer)
+	errWide := PrintList("wide", table, nil, wideBuffer)
+
+	// Assert
+	require.NoError(t, errDefault)
+	require.NoError(t, errWide)
+	require.NotContains(t, defaultBuffer.String(), "MockStreamDefinition")
+	require.Contains(t, wideBuffer.String(), "MockStreamDefinition")
+}
+
+func newTestObject(name string, phase string) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{"phase": phase},
+	}}
+	object.SetAPIVersion("streaming.sneaksanddata.com/v1")
+	object.SetKind("MockStreamDefinition")
+	object.SetName(name)
+	return object
+}
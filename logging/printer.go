@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
 	apiv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/printers"
@@ -17,7 +16,6 @@ type PrintableObject interface {
 }
 
 func Printer(operation string) printers.ResourcePrinter {
-	_ = v1.AddToScheme(scheme.Scheme)
 	return printers.NewTypeSetter(scheme.Scheme).ToPrinter(&printers.NamePrinter{ShortOutput: false, Operation: operation})
 }
 
@@ -4,8 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/sneaksAndData/kubectl-plugin-arcane/commands"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	arcaneerrors "github.com/sneaksAndData/kubectl-plugin-arcane/errors"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/services"
 	"go.uber.org/fx"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -20,23 +25,38 @@ var Version = "v0.0.0"
 var BuildNumber = "0"
 
 func main() {
+	// Cancel the command on Ctrl+C, so waiting commands can report that they were interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	exitCode := 0
 	app := fx.New(
 		fx.Supply(genericclioptions.NewConfigFlags(true)),
+		fx.Supply(&models.ClientParameters{}),
 
 		fx.Provide(commands.NewStreamCommand),
 		fx.Provide(commands.NewDowntimeStopCommand),
+		fx.Provide(commands.NewDowntimeExpireCommand),
 		fx.Provide(commands.NewDowntimeCommand),
 		fx.Provide(commands.NewDowntimeDeclareCommand),
 		fx.Provide(commands.NewRootCommand),
 		fx.Provide(commands.NewStreamStop),
 		fx.Provide(commands.NewStreamStart),
 		fx.Provide(commands.NewStreamBackfill),
+		fx.Provide(commands.NewStreamBackfillCancel),
+		fx.Provide(commands.NewStreamList),
+		fx.Provide(commands.NewStreamDescribe),
+		fx.Provide(commands.NewStreamRebuild),
 		fx.Provide(commands.NewDowntimeListCommand),
 		fx.Provide(commands.NewDowntimeDetailsCommand),
+		fx.Provide(commands.NewBackfillCommand),
+		fx.Provide(commands.NewBackfillListCommand),
+		fx.Provide(commands.NewBackfillGCCommand),
 
 		fx.Provide(services.NewDowntimeService),
 		fx.Provide(services.NewValidatedBackfillService),
 		fx.Provide(services.NewStreamService),
+		fx.Provide(services.NewStreamRebuildService),
 		fx.Provide(services.NewClientProvider),
 		fx.Provide(services.NewDowntimeProcessorFactory),
 		fx.Provide(services.NewUnstructuredReader),
@@ -45,7 +65,8 @@ func main() {
 		fx.NopLogger,
 		fx.Invoke(
 			func(rootCmd commands.RootCommand, shutDowner fx.Shutdowner, lifeCycle fx.Lifecycle) error {
-				err := rootCmd.GetCommand().ExecuteContext(context.TODO())
+				err := rootCmd.GetCommand().ExecuteContext(ctx)
+				exitCode = arcaneerrors.ExitCode(err)
 				defer func() {
 					shErr := shutDowner.Shutdown()
 					if shErr != nil {
@@ -62,5 +83,10 @@ func main() {
 		),
 	)
 
+	if exitCode != 0 {
+		stop()
+		os.Exit(exitCode)
+	}
+
 	app.Run()
 }
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	cmdinterfaces "github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Operation names recorded in the LastOperationAnnotationKey annotation.
+const (
+	operationStreamStart     = "stream-start"
+	operationStreamStop      = "stream-stop"
+	operationBackfill        = "backfill"
+	operationDowntimeDeclare = "downtime-declare"
+	operationDowntimeStop    = "downtime-stop"
+	operationDowntimeExpire  = "downtime-expire"
+)
+
+// unknownActor is recorded if neither the cluster nor the kubeconfig can tell who performs the operation.
+const unknownActor = "unknown"
+
+// AuditRecord holds the audit information stamped on every object modified by a single command run.
+type AuditRecord struct {
+	Operation string
+	Timestamp time.Time
+	Reason    string
+	Ticket    string
+
+	// actor resolves the user performing the operation the first time an object is modified.
+	actor func() string
+}
+
+// Apply sets the audit annotations on the object. Reason and ticket annotations of a previous operation are
+// removed if they are not given, so they are never attributed to the wrong operation.
+func (r AuditRecord) Apply(object metav1.Object) {
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	annotations[interfaces.LastOperationAnnotationKey] = r.Operation
+	annotations[interfaces.LastModifiedByAnnotationKey] = r.actor()
+	annotations[interfaces.LastModifiedAtAnnotationKey] = r.Timestamp.UTC().Format(time.RFC3339)
+	setOrDelete(annotations, interfaces.ReasonAnnotationKey, r.Reason)
+	setOrDelete(annotations, interfaces.TicketAnnotationKey, r.Ticket)
+
+	object.SetAnnotations(annotations)
+}
+
+// auditor resolves the authenticated user and creates the audit records for the operations.
+type auditor struct {
+	clientProvider cmdinterfaces.ClientProvider
+}
+
+func newAuditor(clientProvider cmdinterfaces.ClientProvider) *auditor {
+	return &auditor{clientProvider: clientProvider}
+}
+
+// NewRecord creates the audit record for the operation. The actor is the user reported by a SelfSubjectReview,
+// falling back to the kubeconfig user if the review is not available, e.g. on older clusters. The review is only
+// created once the first object is modified, and never in client dry run mode, as nothing is sent to the server.
+func (a *auditor) NewRecord(ctx context.Context, operation string, parameters models.AuditParameters, dryRun models.DryRunStrategy) AuditRecord {
+	fallback := parameters.KubeconfigUser
+	if fallback == "" {
+		fallback = unknownActor
+	}
+
+	actor := func() string { return fallback }
+	if dryRun != models.DryRunClient {
+		actor = sync.OnceValue(func() string { return a.resolveActor(ctx, fallback) })
+	}
+
+	return AuditRecord{
+		Operation: operation,
+		Timestamp: time.Now(),
+		Reason:    parameters.Reason,
+		Ticket:    parameters.Ticket,
+		actor:     actor,
+	}
+}
+
+func (a *auditor) resolveActor(ctx context.Context, fallback string) string {
+	unstructuredClient, err := a.clientProvider.ProvideUnstructuredClient()
+	if err != nil || unstructuredClient == nil { // coverage-ignore
+		return fallback
+	}
+
+	review := &authenticationv1.SelfSubjectReview{}
+	err = unstructuredClient.Create(ctx, review)
+	if err != nil || review.Status.UserInfo.Username == "" { // coverage-ignore (depends on the cluster version)
+		return fallback
+	}
+
+	return review.Status.UserInfo.Username
+}
+
+func setOrDelete(values map[string]string, key string, value string) {
+	if value == "" {
+		delete(values, key)
+		return
+	}
+	values[key] = value
+}
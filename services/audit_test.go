@@ -0,0 +1,52 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// countingClientProvider counts the requests for an unstructured client, made for every SelfSubjectReview.
+type countingClientProvider struct {
+	*FakeClientProvider
+	calls int
+}
+
+func (p *countingClientProvider) ProvideUnstructuredClient() (client.Client, error) {
+	p.calls++
+	return p.FakeClientProvider.ProvideUnstructuredClient()
+}
+
+func TestAuditor_NewRecord_ResolvesActorOnce(t *testing.T) {
+	// Arrange
+	clientProvider := &countingClientProvider{FakeClientProvider: NewFakeClientProvider(nil, nil)}
+	parameters := models.AuditParameters{KubeconfigUser: "kubeconfig-user"}
+
+	// Act
+	record := newAuditor(clientProvider).NewRecord(t.Context(), operationStreamStop, parameters, models.DryRunNone)
+	require.Zero(t, clientProvider.calls)
+	for range 3 {
+		record.Apply(&unstructured.Unstructured{})
+	}
+
+	// Assert
+	require.Equal(t, 1, clientProvider.calls)
+}
+
+func TestAuditor_NewRecord_ClientDryRun(t *testing.T) {
+	// Arrange
+	clientProvider := &countingClientProvider{FakeClientProvider: NewFakeClientProvider(nil, nil)}
+	parameters := models.AuditParameters{KubeconfigUser: "kubeconfig-user"}
+	object := &unstructured.Unstructured{}
+
+	// Act
+	newAuditor(clientProvider).NewRecord(t.Context(), operationStreamStop, parameters, models.DryRunClient).Apply(object)
+
+	// Assert
+	require.Zero(t, clientProvider.calls)
+	require.Equal(t, "kubeconfig-user", object.GetAnnotations()[interfaces.LastModifiedByAnnotationKey])
+}
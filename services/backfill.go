@@ -4,28 +4,47 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	v1 "github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
 	"github.com/SneaksAndData/arcane-operator/pkg/generated/clientset/versioned"
+	"github.com/SneaksAndData/arcane-operator/services/job"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/errors"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/logging"
+	serviceinterfaces "github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
 )
 
 var _ interfaces.BackfillService = (*backfill)(nil)
 
+// backfillProgressInterval is the interval in which the progress of a backfill is reported while waiting for it.
+const backfillProgressInterval = 30 * time.Second
+
 // backfill is a service that provides backfill operations.
 type backfill struct {
 	clientProvider interfaces.ClientProvider
+	executionQueue serviceinterfaces.ExecutionQueue
+	auditor        *auditor
 }
 
 // newBackfillService creates a new instance of the backfill, which provides backfill operations.
 func newBackfillService(clientProvider interfaces.ClientProvider) interfaces.BackfillService {
 	return &backfill{
 		clientProvider: clientProvider,
+		executionQueue: NewExecutionQueue(clientProvider),
+		auditor:        newAuditor(clientProvider),
 	}
 }
 
@@ -36,68 +55,437 @@ func (b *backfill) Backfill(ctx context.Context, parameters *models.BackfillPara
 		return fmt.Errorf("error providing client set: %w", err)
 	}
 
-	bfr, err := b.getBackfillRequest(ctx, clientSet, parameters.Namespace, parameters.StreamId)
-	if err != nil {
-		return fmt.Errorf("error checking for existence of an backfill request: %w", err)
+	if parameters.Selection != nil {
+		return b.backfillSelection(ctx, clientSet, parameters)
 	}
-	if bfr == nil {
-		bfr, err = clientSet.
-			StreamingV1().
-			BackfillRequests(parameters.Namespace).
-			Create(ctx, parameters.ToBackfillRequest(), metav1.CreateOptions{
-				FieldManager:    fieldManager,
-				FieldValidation: "Strict",
-			})
+
+	bfr, created, err := b.submit(ctx, clientSet, parameters)
+	switch {
+	case err != nil:
+		return err
+	case !created:
+		return b.print(bfr, parameters.Output, "already exists")
+	case !parameters.Wait:
+		return b.print(bfr, parameters.Output, parameters.DryRun.Operation("created"))
+	}
+
+	// Structured output only contains the completed backfill request, so it can be parsed as a single document
+	if !logging.IsStructuredOutput(parameters.Output) {
+		err = b.print(bfr, parameters.Output, "started")
 		if err != nil {
-			return fmt.Errorf("error creating backfill request: %w", err)
+			return err
 		}
+	}
+	waitCtx := ctx
+	if parameters.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, parameters.Timeout)
+		defer cancel()
+	}
 
-		if !parameters.Wait {
-			return logging.Printer("created").PrintObj(bfr, os.Stdout)
+	name := types.NamespacedName{Namespace: parameters.Namespace, Name: bfr.Name}
+	completed, err := b.waitForCompletion(waitCtx, clientSet, bfr)
+	switch {
+	case waitCtx.Err() == context.DeadlineExceeded:
+		return errors.NewTimeoutError(fmt.Errorf("timed out after %s waiting for backfill request %s to complete, the backfill continues in the cluster", parameters.Timeout, name))
+	case waitCtx.Err() == context.Canceled:
+		return errors.NewInterruptedError(fmt.Errorf("interrupted while waiting for backfill request %s to complete, the backfill continues in the cluster", name))
+	case err != nil:
+		return err
+	}
+
+	outcome, err := b.resolveBackfillOutcome(ctx, clientSet, parameters.StreamClass, completed)
+	if err != nil {
+		return fmt.Errorf("error resolving the outcome of backfill request %s: %w", name, err)
+	}
+	elapsed := outcome.Elapsed.Round(time.Second)
+	if !outcome.Succeeded {
+		err = b.print(completed, parameters.Output, fmt.Sprintf("failed after %s", elapsed))
+		if err != nil {
+			return err
 		}
-	} else {
-		return logging.Printer("already exists").PrintObj(bfr, os.Stdout)
+		return errors.NewFailedError(fmt.Errorf("backfill request %s failed after %s: %s", name, elapsed, outcome.LastError))
 	}
+	return b.print(completed, parameters.Output, fmt.Sprintf("succeeded in %s", elapsed))
+}
 
-	err = logging.Printer("started").PrintObj(bfr, os.Stdout)
+// backfillSelection creates a backfill request for every stream of the selection through the execution queue and
+// prints a summary of the results.
+func (b *backfill) backfillSelection(ctx context.Context, clientSet *versioned.Clientset, parameters *models.BackfillParameters) error {
+	membersPublisher, err := newStreamSelectionPublisher(b.clientProvider, parameters.StreamClass, parameters.Namespace, parameters.Selection)
 	if err != nil {
 		return err
 	}
-	return wait.PollUntilContextCancel(ctx, 1*time.Second, true, func(ctx context.Context) (done bool, err error) {
-		watch, err := clientSet.StreamingV1().BackfillRequests(parameters.Namespace).Watch(ctx, metav1.ListOptions{
-			FieldSelector:   "metadata.name=" + bfr.Name,
-			Watch:           true,
-			ResourceVersion: bfr.ResourceVersion,
-		})
+
+	if parameters.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, parameters.Timeout)
+		defer cancel()
+	}
+
+	processor := newStreamBackfillProcessor(b, clientSet, parameters)
+	err = b.executionQueue.ProcessQueue(ctx, processor, logging.Printer(""), membersPublisher, serviceinterfaces.QueueOptions{DryRun: parameters.DryRun})
+	results := processor.Wait()
+	if err != nil { // coverage-ignore
+		return err
+	}
+
+	// The summary is written to stderr, so it does not mix with the structured output of the backfill requests
+	err = printBackfillSummary(os.Stderr, results)
+	if err != nil { // coverage-ignore
+		return err
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Result == backfillResultFailed {
+			failed++
+		}
+	}
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		return errors.NewTimeoutError(fmt.Errorf("timed out after %s waiting for the backfills of stream class %s to complete, started backfills continue in the cluster", parameters.Timeout, parameters.StreamClass))
+	case ctx.Err() == context.Canceled:
+		return errors.NewInterruptedError(fmt.Errorf("interrupted while waiting for the backfills of stream class %s to complete, started backfills continue in the cluster", parameters.StreamClass))
+	case failed > 0:
+		return errors.NewFailedError(fmt.Errorf("%d of %d backfills failed", failed, len(results)))
+	}
+	return nil
+}
+
+// submit creates a backfill request for the stream, unless the stream has an active backfill request already, which
+// is cancelled first if parameters.Replace is set. It returns the active or created request and whether it was created.
+func (b *backfill) submit(ctx context.Context, clientSet *versioned.Clientset, parameters *models.BackfillParameters) (*v1.BackfillRequest, bool, error) {
+	bfr, err := b.getBackfillRequest(ctx, clientSet, parameters.Namespace, parameters.StreamClass, parameters.StreamId)
+	if err != nil {
+		return nil, false, fmt.Errorf("error checking for existence of an backfill request: %w", err)
+	}
+	if bfr != nil && !parameters.Replace {
+		return bfr, false, nil
+	}
+	if bfr != nil {
+		err = b.cancel(ctx, clientSet, bfr, parameters.DryRun, parameters.Output)
 		if err != nil {
-			return false, fmt.Errorf("error watching backfill request: %w", err)
+			return nil, false, err
+		}
+		// The operator keeps the backfill job running if a new request is created before the job of the
+		// cancelled request is removed, so the new request must wait for it
+		if parameters.DryRun == models.DryRunNone {
+			err = b.waitForBackfillJob(ctx, types.NamespacedName{Namespace: parameters.Namespace, Name: parameters.StreamId}, parameters.Timeout)
+			if err != nil {
+				return nil, false, err
+			}
 		}
-		defer watch.Stop()
+	}
 
-		for {
+	request := parameters.ToBackfillRequest()
+	b.auditor.NewRecord(ctx, operationBackfill, parameters.Audit, parameters.DryRun).Apply(request)
+	if parameters.DryRun == models.DryRunClient {
+		request.Namespace = parameters.Namespace
+		return request, true, nil
+	}
+
+	createOptions := metav1.CreateOptions{
+		FieldManager:    fieldManager,
+		FieldValidation: "Strict",
+	}
+	if parameters.DryRun == models.DryRunServer {
+		createOptions.DryRun = []string{metav1.DryRunAll}
+	}
+	bfr, err = clientSet.
+		StreamingV1().
+		BackfillRequests(parameters.Namespace).
+		Create(ctx, request, createOptions)
+	if err != nil {
+		return nil, false, fmt.Errorf("error creating backfill request: %w", err)
+	}
+	return bfr, true, nil
+}
+
+// waitForCompletion watches the backfill request until it is completed. The watch is resumed from the latest
+// observed resource version if it is closed, and the request is read again if that version has expired, so
+// long-running backfills do not miss the completion. Progress is reported on stderr in regular intervals.
+func (b *backfill) waitForCompletion(ctx context.Context, clientSet *versioned.Clientset, bfr *v1.BackfillRequest) (*v1.BackfillRequest, error) {
+	requests := clientSet.StreamingV1().BackfillRequests(bfr.Namespace)
+	listWatch := &cache.ListWatch{
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = "metadata.name=" + bfr.Name
+			return requests.Watch(ctx, options)
+		},
+	}
+
+	started := time.Now()
+	progress := time.NewTicker(backfillProgressInterval)
+	defer progress.Stop()
+
+	for {
+		if bfr.Spec.Completed {
+			return bfr, nil
+		}
+
+		watcher, err := watchtools.NewRetryWatcherWithContext(ctx, bfr.ResourceVersion, listWatch)
+		if err != nil { // coverage-ignore
+			return nil, fmt.Errorf("error watching backfill request: %w", err)
+		}
+
+		expired := false
+		for !expired && !bfr.Spec.Completed {
 			select {
-			case event, ok := <-watch.ResultChan():
+			case event, ok := <-watcher.ResultChan():
 				if !ok {
-					logging.LogError(bfr, "watching backfill request, retrying", fmt.Errorf("watch channel closed"))
-					return false, nil // watch channel closed, retry
+					expired = true
+					continue
 				}
-				bfr, ok := event.Object.(*v1.BackfillRequest)
-				if !ok {
-					return false, fmt.Errorf("unexpected object type: %T", event.Object)
+				switch event.Type {
+				case watch.Error:
+					err = apierrors.FromObject(event.Object)
+					if !apierrors.IsResourceExpired(err) && !apierrors.IsGone(err) {
+						watcher.Stop()
+						return nil, fmt.Errorf("error watching backfill request: %w", err)
+					}
+					expired = true
+				case watch.Deleted:
+					watcher.Stop()
+					return nil, fmt.Errorf("backfill request %s/%s was deleted before it completed", bfr.Namespace, bfr.Name)
+				default:
+					updated, ok := event.Object.(*v1.BackfillRequest)
+					if !ok { // coverage-ignore
+						watcher.Stop()
+						return nil, fmt.Errorf("unexpected object type: %T", event.Object)
+					}
+					bfr = updated
 				}
 
-				if bfr.Spec.Completed {
-					return true, logging.Printer("completed").PrintObj(bfr, os.Stdout)
-				}
+			case <-progress.C:
+				logging.LogProgress(withBackfillRequestKind(bfr), fmt.Sprintf("still running after %s", duration.HumanDuration(time.Since(started))))
 
 			case <-ctx.Done():
-				return true, ctx.Err()
+				watcher.Stop()
+				return nil, ctx.Err()
 			}
 		}
+		watcher.Stop()
+
+		if bfr.Spec.Completed {
+			return bfr, nil
+		}
+
+		// The watch cannot be resumed, read the request again to continue from its current resource version
+		bfr, err = requests.Get(ctx, bfr.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("backfill request was deleted before it completed: %w", err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading backfill request: %w", err)
+		}
+	}
+}
+
+// withBackfillRequestKind sets the kind of the backfill request, which is not set on objects returned by the typed client.
+func withBackfillRequestKind(bfr *v1.BackfillRequest) *v1.BackfillRequest {
+	withKind := bfr.DeepCopy()
+	withKind.SetGroupVersionKind(v1.SchemeGroupVersion.WithKind("BackfillRequest"))
+	return withKind
+}
+
+// Cancel is a method that allows users to cancel the active backfill request of a stream
+func (b *backfill) Cancel(ctx context.Context, parameters *models.BackfillCancelParameters) error {
+	clientSet, err := b.clientProvider.ProvideClientSet()
+	if err != nil {
+		return fmt.Errorf("error providing client set: %w", err)
+	}
+
+	bfr, err := b.getBackfillRequest(ctx, clientSet, parameters.Namespace, parameters.StreamClass, parameters.StreamId)
+	if err != nil {
+		return fmt.Errorf("error checking for existence of an backfill request: %w", err)
+	}
+	if bfr == nil {
+		return errors.NewNoActiveBackfillError(parameters.StreamClass, types.NamespacedName{Namespace: parameters.Namespace, Name: parameters.StreamId})
+	}
+
+	err = b.cancel(ctx, clientSet, bfr, parameters.DryRun, parameters.Output)
+	if err != nil || !parameters.Wait {
+		return err
+	}
+
+	return b.waitForBackfillJob(ctx, types.NamespacedName{Namespace: parameters.Namespace, Name: parameters.StreamId}, parameters.Timeout)
+}
+
+// cancel deletes the backfill request. The operator only reacts to the deletion of a backfill request that is
+// not completed, so deleting it is the way to make the operator remove the backfill job.
+func (b *backfill) cancel(ctx context.Context, clientSet *versioned.Clientset, bfr *v1.BackfillRequest, dryRun models.DryRunStrategy, output string) error {
+	if dryRun != models.DryRunClient {
+		deleteOptions := metav1.DeleteOptions{
+			// Do not delete a request that was replaced by someone else in the meantime
+			Preconditions: &metav1.Preconditions{UID: &bfr.UID},
+		}
+		if dryRun == models.DryRunServer {
+			deleteOptions.DryRun = []string{metav1.DryRunAll}
+		}
+		err := clientSet.StreamingV1().BackfillRequests(bfr.Namespace).Delete(ctx, bfr.Name, deleteOptions)
+		if err != nil {
+			return fmt.Errorf("error deleting backfill request: %w", err)
+		}
+	}
+
+	return b.print(bfr, output, dryRun.Operation("cancelled"))
+}
+
+// waitForBackfillJob polls the job of the stream until it is removed or replaced by a job that is not a backfill job.
+func (b *backfill) waitForBackfillJob(ctx context.Context, namespacedName types.NamespacedName, timeout time.Duration) error {
+	unstructuredClient, err := b.clientProvider.ProvideUnstructuredClient()
+	if err != nil {
+		return fmt.Errorf("error providing unstructured client: %w", err)
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err = wait.PollUntilContextCancel(ctx, 1*time.Second, true, func(ctx context.Context) (done bool, err error) {
+		backfillJob := &batchv1.Job{}
+		err = unstructuredClient.Get(ctx, namespacedName, backfillJob)
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("error fetching backfill job: %w", err)
+		}
+		return backfillJob.Labels[job.BackfillLabel] != "true", nil
+	})
+	if wait.Interrupted(err) && ctx.Err() == context.DeadlineExceeded {
+		return errors.NewTimeoutError(fmt.Errorf("timed out after %s waiting for the backfill job of stream %s to terminate", timeout, namespacedName))
+	}
+	return err
+}
+
+// List is a method that allows users to list backfill requests, optionally filtered by stream class, stream id and completion state
+func (b *backfill) List(ctx context.Context, parameters *models.BackfillListParameters) (interfaces.BackfillInventory, error) {
+	clientSet, err := b.clientProvider.ProvideClientSet()
+	if err != nil {
+		return nil, fmt.Errorf("error providing client set: %w", err)
+	}
+
+	var fieldSelectors []string
+	if parameters.StreamId != "" {
+		fieldSelectors = append(fieldSelectors, "spec.streamId="+parameters.StreamId)
+	}
+	switch {
+	case parameters.Active:
+		fieldSelectors = append(fieldSelectors, "spec.completed=false")
+	case parameters.Completed:
+		fieldSelectors = append(fieldSelectors, "spec.completed=true")
+	}
+
+	list, err := clientSet.
+		StreamingV1().
+		BackfillRequests(parameters.Namespace).
+		List(ctx, metav1.ListOptions{FieldSelector: strings.Join(fieldSelectors, ",")})
+	if err != nil {
+		return nil, fmt.Errorf("error listing backfill requests: %w", err)
+	}
+
+	// The stream class is not a selectable field of the backfill request, so it is filtered on the client side
+	var requests []v1.BackfillRequest
+	for _, bfr := range list.Items {
+		if parameters.StreamClass == "" || bfr.Spec.StreamClass == parameters.StreamClass {
+			requests = append(requests, bfr)
+		}
+	}
+
+	return NewBackfillInventory(requests), nil
+}
+
+// GC is a method that allows users to delete completed backfill requests by age and retention count per stream
+func (b *backfill) GC(ctx context.Context, parameters *models.BackfillGCParameters) error {
+	clientSet, err := b.clientProvider.ProvideClientSet()
+	if err != nil {
+		return fmt.Errorf("error providing client set: %w", err)
+	}
+
+	list, err := clientSet.
+		StreamingV1().
+		BackfillRequests(parameters.Namespace).
+		List(ctx, metav1.ListOptions{FieldSelector: "spec.completed=true"})
+	if err != nil {
+		return fmt.Errorf("error listing backfill requests: %w", err)
+	}
+
+	var requests []v1.BackfillRequest
+	for _, bfr := range list.Items {
+		if parameters.StreamClass == "" || bfr.Spec.StreamClass == parameters.StreamClass {
+			requests = append(requests, bfr)
+		}
+	}
+
+	for _, bfr := range expiredBackfillRequests(requests, parameters.KeepLast, parameters.OlderThan, time.Now()) {
+		if parameters.DryRun != models.DryRunClient {
+			deleteOptions := metav1.DeleteOptions{
+				Preconditions: &metav1.Preconditions{UID: &bfr.UID},
+			}
+			if parameters.DryRun == models.DryRunServer {
+				deleteOptions.DryRun = []string{metav1.DryRunAll}
+			}
+			err = clientSet.StreamingV1().BackfillRequests(bfr.Namespace).Delete(ctx, bfr.Name, deleteOptions)
+			if apierrors.IsNotFound(err) {
+				continue // Deleted concurrently
+			}
+			if err != nil {
+				return fmt.Errorf("error deleting backfill request %s/%s: %w", bfr.Namespace, bfr.Name, err)
+			}
+		}
+
+		err = b.print(&bfr, parameters.Output, parameters.DryRun.Operation("deleted"))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expiredBackfillRequests returns the completed backfill requests that are not among the keepLast most recent ones of
+// their stream and, if olderThan is set, were created more than olderThan before now, oldest first.
+func expiredBackfillRequests(requests []v1.BackfillRequest, keepLast int, olderThan time.Duration, now time.Time) []v1.BackfillRequest {
+	byStream := map[string][]v1.BackfillRequest{}
+	for _, bfr := range requests {
+		key := strings.Join([]string{bfr.Namespace, bfr.Spec.StreamClass, bfr.Spec.StreamId}, "/")
+		byStream[key] = append(byStream[key], bfr)
+	}
+
+	var expired []v1.BackfillRequest
+	for _, streamRequests := range byStream {
+		sort.SliceStable(streamRequests, func(i, j int) bool {
+			return streamRequests[j].CreationTimestamp.Before(&streamRequests[i].CreationTimestamp)
+		})
+		for i, bfr := range streamRequests {
+			if i < keepLast {
+				continue
+			}
+			if olderThan > 0 && now.Sub(bfr.CreationTimestamp.Time) < olderThan {
+				continue
+			}
+			expired = append(expired, bfr)
+		}
+	}
+
+	sort.SliceStable(expired, func(i, j int) bool {
+		return expired[i].CreationTimestamp.Before(&expired[j].CreationTimestamp)
 	})
+	return expired
+}
+
+func (b *backfill) print(bfr *v1.BackfillRequest, output string, operation string) error {
+	printer, err := logging.NewPrinter(output, operation)
+	if err != nil { // coverage-ignore (validated by the command parameters)
+		return err
+	}
+	return printer.PrintObj(bfr, os.Stdout)
 }
 
-func (b *backfill) getBackfillRequest(ctx context.Context, clientSet *versioned.Clientset, namespace string, id string) (*v1.BackfillRequest, error) {
+// getBackfillRequest returns the active backfill request of the stream of the stream class, or nil if there is none.
+func (b *backfill) getBackfillRequest(ctx context.Context, clientSet *versioned.Clientset, namespace string, streamClass string, id string) (*v1.BackfillRequest, error) {
 	list, err := clientSet.
 		StreamingV1().
 		BackfillRequests(namespace).
@@ -109,13 +497,27 @@ func (b *backfill) getBackfillRequest(ctx context.Context, clientSet *versioned.
 		return nil, fmt.Errorf("error listing backfill requests: %w", err)
 	}
 
-	if len(list.Items) > 1 {
-		return nil, fmt.Errorf("multiple active backfill requests found for stream %s in namespace %s", namespace, namespace)
+	// Streams of different stream classes can have the same name, and the stream class is not a selectable field
+	// of the backfill request, so it is matched on the client side
+	var requests []*v1.BackfillRequest
+	for i := range list.Items {
+		if list.Items[i].Spec.StreamClass == streamClass {
+			requests = append(requests, &list.Items[i])
+		}
+	}
+
+	if len(requests) > 1 {
+		names := make([]string, len(requests))
+		for i, bfr := range requests {
+			names[i] = bfr.Name
+		}
+		return nil, fmt.Errorf("multiple active backfill requests found for stream %s of stream class %s in namespace %s: %s",
+			id, streamClass, namespace, strings.Join(names, ", "))
 	}
 
-	if len(list.Items) == 0 {
+	if len(requests) == 0 {
 		return nil, nil
 	}
 
-	return &list.Items[0], nil
+	return requests[0], nil
 }
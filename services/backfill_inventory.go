@@ -0,0 +1,83 @@
+package services
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	v1 "github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
+	svcinterfaces "github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ interfaces.BackfillInventory = (*BackfillInventory)(nil)
+
+type BackfillInventory struct {
+	requests []v1.BackfillRequest
+}
+
+func NewBackfillInventory(requests []v1.BackfillRequest) *BackfillInventory {
+	sorted := make([]v1.BackfillRequest, len(requests))
+	copy(sorted, requests)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Namespace != sorted[j].Namespace {
+			return sorted[i].Namespace < sorted[j].Namespace
+		}
+		if !sorted[i].CreationTimestamp.Equal(&sorted[j].CreationTimestamp) {
+			return sorted[i].CreationTimestamp.Before(&sorted[j].CreationTimestamp)
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return &BackfillInventory{requests: sorted}
+}
+
+func (b *BackfillInventory) Table() *metav1.Table { // coverage-ignore (tested in integration tests)
+	table := &metav1.Table{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Table",
+			APIVersion: "meta.k8s.io/v1",
+		},
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Namespace", Type: "string"},
+			{Name: "Name", Type: "string"},
+			{Name: "Stream Class", Type: "string"},
+			{Name: "Stream Id", Type: "string"},
+			{Name: "Completed", Type: "boolean"},
+			{Name: "Created", Type: "string"},
+			{Name: "Age", Type: "string"},
+			{Name: "Requested By", Type: "string", Priority: 1},
+			{Name: "Reason", Type: "string", Priority: 1},
+		},
+	}
+
+	for _, bfr := range b.requests {
+		row := metav1.TableRow{
+			Cells: []interface{}{
+				bfr.Namespace,
+				bfr.Name,
+				bfr.Spec.StreamClass,
+				bfr.Spec.StreamId,
+				strconv.FormatBool(bfr.Spec.Completed),
+				bfr.CreationTimestamp.UTC().Format(time.RFC3339),
+				age(bfr.CreationTimestamp),
+				annotationOrNone(bfr.Annotations, svcinterfaces.LastModifiedByAnnotationKey),
+				annotationOrNone(bfr.Annotations, svcinterfaces.ReasonAnnotationKey),
+			},
+		}
+		table.Rows = append(table.Rows, row)
+	}
+
+	return table
+}
+
+func (b *BackfillInventory) Objects() *v1.BackfillRequestList {
+	list := &v1.BackfillRequestList{}
+	list.SetGroupVersionKind(v1.SchemeGroupVersion.WithKind("BackfillRequestList"))
+	for _, bfr := range b.requests {
+		item := *bfr.DeepCopy()
+		item.SetGroupVersionKind(v1.SchemeGroupVersion.WithKind("BackfillRequest"))
+		list.Items = append(list.Items, item)
+	}
+	return list
+}
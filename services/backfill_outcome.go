@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
+	"github.com/SneaksAndData/arcane-operator/pkg/generated/clientset/versioned"
+	"github.com/SneaksAndData/arcane-operator/services/controllers/contracts"
+	streamapis "github.com/SneaksAndData/arcane-operator/services/controllers/stream"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// backfillOutcomeGracePeriod is the time to wait for the events that tell the outcome of a completed backfill,
+// as the operator emits them after the backfill request is marked as completed.
+var backfillOutcomeGracePeriod = 10 * time.Second
+
+// backfillFailureReasons are the reasons of the events emitted by the operator and the job controller for a failed backfill job.
+// The stream and its jobs have the same name, so the events of both are found by the name of the stream.
+var backfillFailureReasons = map[string]bool{
+	"StreamingJobFailed":   true,
+	"BackoffLimitExceeded": true,
+	"DeadlineExceeded":     true,
+}
+
+// backfillSucceededReason is the reason of the event emitted by the operator for a completed backfill.
+const backfillSucceededReason = "BackfillCompleted"
+
+// backfillOutcome is the result of a completed backfill request.
+type backfillOutcome struct {
+	Succeeded bool
+	Elapsed   time.Duration
+	LastError string
+}
+
+// resolveBackfillOutcome determines whether the completed backfill request succeeded, based on its status, the
+// events of the stream and its backfill job and, as a last resort, the phase of the stream.
+func (b *backfill) resolveBackfillOutcome(ctx context.Context, clientSet *versioned.Clientset, streamClass string, bfr *v1.BackfillRequest) (*backfillOutcome, error) {
+	outcome := &backfillOutcome{Succeeded: true, Elapsed: time.Since(bfr.CreationTimestamp.Time)}
+
+	if bfr.Status.Phase == v1.PhaseFailed {
+		outcome.Succeeded = false
+		outcome.LastError = lastConditionMessage(bfr.Status.Conditions)
+		return outcome, nil
+	}
+
+	unstructuredClient, err := b.clientProvider.ProvideUnstructuredClient()
+	if err != nil {
+		return nil, fmt.Errorf("error providing unstructured client: %w", err)
+	}
+
+	resolved := false
+	err = wait.PollUntilContextTimeout(ctx, 1*time.Second, backfillOutcomeGracePeriod, true, func(ctx context.Context) (bool, error) {
+		events, err := backfillEvents(ctx, unstructuredClient, bfr)
+		if err != nil {
+			return false, err
+		}
+		resolved, outcome.Succeeded, outcome.LastError = backfillOutcomeFromEvents(events)
+		return resolved, nil
+	})
+	if resolved || (err != nil && !wait.Interrupted(err)) {
+		return outcome, err
+	}
+
+	// No backfill event tells the outcome, the operator moves the stream to the Failed phase if the backfill job fails
+	phase, err := b.streamPhase(ctx, clientSet, unstructuredClient, streamClass, types.NamespacedName{Namespace: bfr.Namespace, Name: bfr.Spec.StreamId})
+	if err != nil {
+		return nil, err
+	}
+	if phase == streamapis.Failed {
+		outcome.Succeeded = false
+		outcome.LastError = fmt.Sprintf("stream %s/%s is in phase %s", bfr.Namespace, bfr.Spec.StreamId, phase)
+	}
+	return outcome, nil
+}
+
+// backfillOutcomeFromEvents returns the outcome told by the first terminal backfill event, oldest first. The events
+// that follow it belong to the streaming job started after the backfill, so a later failure does not fail the backfill.
+func backfillOutcomeFromEvents(events []corev1.Event) (resolved bool, succeeded bool, lastError string) {
+	for _, event := range events {
+		switch {
+		case backfillFailureReasons[event.Reason]:
+			return true, false, strings.TrimSpace(event.Message)
+		case event.Reason == backfillSucceededReason:
+			return true, true, ""
+		}
+	}
+	return false, true, ""
+}
+
+// backfillEvents returns the events of the stream and its jobs emitted since the backfill request was created, oldest first.
+func backfillEvents(ctx context.Context, unstructuredClient client.Client, bfr *v1.BackfillRequest) ([]corev1.Event, error) {
+	eventList := &corev1.EventList{}
+	err := unstructuredClient.List(ctx, eventList, client.InNamespace(bfr.Namespace), client.MatchingFields{"involvedObject.name": bfr.Spec.StreamId})
+	if err != nil {
+		return nil, fmt.Errorf("error listing events: %w", err)
+	}
+
+	var events []corev1.Event
+	for _, event := range eventList.Items {
+		if !eventTime(event).Before(bfr.CreationTimestamp.Time) {
+			events = append(events, event)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+	return events, nil
+}
+
+func (b *backfill) streamPhase(ctx context.Context, clientSet *versioned.Clientset, unstructuredClient client.Client, streamClass string, name types.NamespacedName) (streamapis.Phase, error) {
+	sc, err := clientSet.StreamingV1().StreamClasses("").Get(ctx, streamClass, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error fetching stream class: %w", err)
+	}
+
+	definition, err := streamapis.GetStreamForClass(ctx, unstructuredClient, sc, name, contracts.FromUnstructured)
+	if err != nil {
+		return "", fmt.Errorf("error fetching stream definition: %w", err)
+	}
+	return definition.GetPhase(), nil
+}
+
+func lastConditionMessage(conditions []metav1.Condition) string {
+	if len(conditions) == 0 {
+		return "<none>"
+	}
+	last := conditions[0]
+	for _, condition := range conditions[1:] {
+		if last.LastTransitionTime.Before(&condition.LastTransitionTime) {
+			last = condition
+		}
+	}
+	return last.Message
+}
@@ -7,13 +7,17 @@ import (
 	"testing"
 	"time"
 
+	v1 "github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
 	versionedv1 "github.com/SneaksAndData/arcane-operator/pkg/generated/clientset/versioned"
 	streamapis "github.com/SneaksAndData/arcane-operator/services/controllers/stream"
 	mockv1 "github.com/SneaksAndData/arcane-stream-mock/pkg/apis/streaming/v1"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/errors"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/tests/helpers"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func Test_Backfill(t *testing.T) {
@@ -35,14 +39,38 @@ func Test_Backfill(t *testing.T) {
 	require.False(t, bfr.Spec.Completed)
 }
 
-func Test_Backfill_Wait(t *testing.T) {
+func Test_Backfill_DryRun(t *testing.T) {
 	name := createTestStreamDefinition(t, false, "5s", true)
 	require.NotEmpty(t, name)
 
 	clientSet := versionedv1.NewForConfigOrDie(kubeConfig)
 
 	backfillService := newBackfillService(NewFakeClientProvider(clientSet, nil))
-	err := backfillService.Backfill(t.Context(), &models.BackfillParameters{
+	for _, dryRun := range []models.DryRunStrategy{models.DryRunClient, models.DryRunServer} {
+		err := backfillService.Backfill(t.Context(), &models.BackfillParameters{
+			Namespace:   "default",
+			StreamId:    name,
+			StreamClass: "arcane-stream-mock",
+			DryRun:      dryRun,
+		})
+		require.NoError(t, err)
+	}
+
+	bfr, err := findBackfillRequestByName(t.Context(), "default", name)
+	require.Error(t, err)
+	require.Nil(t, bfr)
+}
+
+func Test_Backfill_Wait(t *testing.T) {
+	name := createTestStreamDefinition(t, false, "5s", true)
+	require.NotEmpty(t, name)
+
+	clientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+	c, err := client.New(kubeConfig, client.Options{})
+	require.NoError(t, err)
+
+	backfillService := newBackfillService(NewFakeClientProvider(clientSet, c))
+	err = backfillService.Backfill(t.Context(), &models.BackfillParameters{
 		Namespace:   "default",
 		StreamId:    name,
 		StreamClass: "arcane-stream-mock",
@@ -54,6 +82,25 @@ func Test_Backfill_Wait(t *testing.T) {
 	require.True(t, bfr.Spec.Completed)
 }
 
+func Test_Backfill_WaitFailed(t *testing.T) {
+	name := createTestStreamDefinition(t, true, "5s", true)
+	require.NotEmpty(t, name)
+
+	clientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+	c, err := client.New(kubeConfig, client.Options{})
+	require.NoError(t, err)
+
+	backfillService := newBackfillService(NewFakeClientProvider(clientSet, c))
+	err = backfillService.Backfill(t.Context(), &models.BackfillParameters{
+		Namespace:   "default",
+		StreamId:    name,
+		StreamClass: "arcane-stream-mock",
+		Wait:        true,
+	})
+	require.ErrorContains(t, err, "failed after")
+	require.Equal(t, errors.ExitCodeFailed, errors.ExitCode(err))
+}
+
 func Test_Backfill_Duplicate(t *testing.T) {
 	name := helpers.NewTestStream(t, clientSet, func(def *mockv1.TestStreamDefinition) {
 		def.Spec.RunDuration = "30m"
@@ -154,3 +201,285 @@ func Test_Backfill_Cancelled(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, bfr.Spec.Completed, "backfill should not be completed when context is cancelled")
 }
+
+func Test_Backfill_WaitTimeout(t *testing.T) {
+	name := createTestStreamDefinition(t, false, "60s", true)
+	require.NotEmpty(t, name)
+
+	clientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+
+	backfillService := newBackfillService(NewFakeClientProvider(clientSet, nil))
+	err := backfillService.Backfill(t.Context(), &models.BackfillParameters{
+		Namespace:   "default",
+		StreamId:    name,
+		StreamClass: "arcane-stream-mock",
+		Wait:        true,
+		Timeout:     time.Second,
+	})
+	require.ErrorContains(t, err, "timed out")
+	require.Equal(t, errors.ExitCodeTimeout, errors.ExitCode(err))
+}
+
+func Test_Backfill_List(t *testing.T) {
+	name := createTestStreamDefinition(t, false, "5s", true)
+	require.NotEmpty(t, name)
+
+	clientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+
+	backfillService := newBackfillService(NewFakeClientProvider(clientSet, nil))
+	err := backfillService.Backfill(t.Context(), &models.BackfillParameters{
+		Namespace:   "default",
+		StreamId:    name,
+		StreamClass: "arcane-stream-mock",
+	})
+	require.NoError(t, err)
+
+	inventory, err := backfillService.List(t.Context(), &models.BackfillListParameters{
+		Namespace:   "default",
+		StreamId:    name,
+		StreamClass: "arcane-stream-mock",
+		Active:      true,
+	})
+	require.NoError(t, err)
+	require.Len(t, inventory.Objects().Items, 1)
+	require.Equal(t, name, inventory.Objects().Items[0].Spec.StreamId)
+
+	inventory, err = backfillService.List(t.Context(), &models.BackfillListParameters{
+		Namespace:   "default",
+		StreamId:    name,
+		StreamClass: "another-stream-class",
+	})
+	require.NoError(t, err)
+	require.Empty(t, inventory.Objects().Items)
+}
+
+func Test_Backfill_Cancel(t *testing.T) {
+	name := createTestStreamDefinition(t, false, "5s", true)
+	require.NotEmpty(t, name)
+
+	clientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+	c, err := client.New(kubeConfig, client.Options{})
+	require.NoError(t, err)
+
+	backfillService := newBackfillService(NewFakeClientProvider(clientSet, c))
+	err = backfillService.Backfill(t.Context(), &models.BackfillParameters{
+		Namespace:   "default",
+		StreamId:    name,
+		StreamClass: "arcane-stream-mock",
+	})
+	require.NoError(t, err)
+
+	err = backfillService.Cancel(t.Context(), &models.BackfillCancelParameters{
+		Namespace:   "default",
+		StreamId:    name,
+		StreamClass: "arcane-stream-mock",
+		Wait:        true,
+		Timeout:     time.Minute,
+	})
+	require.NoError(t, err)
+
+	bfr, err := findBackfillRequestByName(t.Context(), "default", name)
+	require.Error(t, err)
+	require.Nil(t, bfr)
+
+	err = backfillService.Cancel(t.Context(), &models.BackfillCancelParameters{
+		Namespace:   "default",
+		StreamId:    name,
+		StreamClass: "arcane-stream-mock",
+	})
+	require.ErrorContains(t, err, "no active backfill request found")
+}
+
+func Test_Backfill_Replace(t *testing.T) {
+	name := createTestStreamDefinition(t, false, "5s", true)
+	require.NotEmpty(t, name)
+
+	clientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+	c, err := client.New(kubeConfig, client.Options{})
+	require.NoError(t, err)
+
+	backfillService := newBackfillService(NewFakeClientProvider(clientSet, c))
+	err = backfillService.Backfill(t.Context(), &models.BackfillParameters{
+		Namespace:   "default",
+		StreamId:    name,
+		StreamClass: "arcane-stream-mock",
+	})
+	require.NoError(t, err)
+	replaced, err := findBackfillRequestByName(t.Context(), "default", name)
+	require.NoError(t, err)
+
+	err = backfillService.Backfill(t.Context(), &models.BackfillParameters{
+		Namespace:   "default",
+		StreamId:    name,
+		StreamClass: "arcane-stream-mock",
+		Replace:     true,
+		Timeout:     time.Minute,
+	})
+	require.NoError(t, err)
+
+	bfr, err := findBackfillRequestByName(t.Context(), "default", name)
+	require.NoError(t, err)
+	require.NotEqual(t, replaced.Name, bfr.Name)
+	require.False(t, bfr.Spec.Completed)
+}
+
+func Test_Backfill_Bulk(t *testing.T) {
+	const streamCount = 3
+	pattern := "bulk-backfill-test-"
+
+	var names []string
+	for range streamCount {
+		name := helpers.NewTestStream(t, clientSet, func(def *mockv1.TestStreamDefinition) {
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = true
+			def.GenerateName = pattern
+		})
+		require.NotEmpty(t, name)
+		names = append(names, name)
+	}
+
+	streamingClientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+	c, err := client.New(kubeConfig, client.Options{})
+	require.NoError(t, err)
+
+	backfillService := newBackfillService(NewFakeClientProvider(streamingClientSet, c))
+	err = backfillService.Backfill(t.Context(), &models.BackfillParameters{
+		Namespace:   "default",
+		StreamClass: "arcane-stream-mock",
+		MaxInFlight: 2,
+		Selection:   &models.StreamSelection{Prefix: pattern},
+	})
+	require.NoError(t, err)
+
+	for _, name := range names {
+		bfr, err := findBackfillRequestByName(t.Context(), "default", name)
+		require.NoError(t, err)
+		require.True(t, bfr.Spec.Completed)
+	}
+}
+
+func Test_Backfill_OtherStreamClass(t *testing.T) {
+	name := createTestStreamDefinition(t, false, "5s", true)
+	require.NotEmpty(t, name)
+
+	clientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+
+	// An active backfill request of a stream with the same name in another stream class must not block the backfill
+	other := (&models.BackfillParameters{StreamClass: "another-stream-class", StreamId: name}).ToBackfillRequest()
+	other, err := clientSet.StreamingV1().BackfillRequests("default").Create(t.Context(), other, metav1.CreateOptions{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = clientSet.StreamingV1().BackfillRequests("default").Delete(context.Background(), other.Name, metav1.DeleteOptions{})
+	})
+
+	backfillService := newBackfillService(NewFakeClientProvider(clientSet, nil))
+	err = backfillService.Backfill(t.Context(), &models.BackfillParameters{
+		Namespace:   "default",
+		StreamId:    name,
+		StreamClass: "arcane-stream-mock",
+	})
+	require.NoError(t, err)
+
+	backfillList, err := clientSet.StreamingV1().BackfillRequests("default").List(t.Context(), metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("spec.completed=false,spec.streamId=%s", name),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(backfillList.Items))
+}
+
+func Test_Backfill_GC(t *testing.T) {
+	name := createTestStreamDefinition(t, false, "5s", true)
+	require.NotEmpty(t, name)
+
+	clientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+	for range 3 {
+		bfr := (&models.BackfillParameters{StreamClass: "arcane-stream-mock", StreamId: name}).ToBackfillRequest()
+		bfr.Spec.Completed = true
+		_, err := clientSet.StreamingV1().BackfillRequests("default").Create(t.Context(), bfr, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	backfillService := newBackfillService(NewFakeClientProvider(clientSet, nil))
+	err := backfillService.GC(t.Context(), &models.BackfillGCParameters{
+		Namespace: "default",
+		KeepLast:  1,
+	})
+	require.NoError(t, err)
+
+	backfillList, err := clientSet.StreamingV1().BackfillRequests("default").List(t.Context(), metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("spec.completed=true,spec.streamId=%s", name),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(backfillList.Items))
+}
+
+func Test_ExpiredBackfillRequests(t *testing.T) {
+	now := time.Now()
+	newRequest := func(name string, streamId string, age time.Duration) v1.BackfillRequest {
+		return v1.BackfillRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.NewTime(now.Add(-age))},
+			Spec:       v1.BackfillRequestSpec{StreamClass: "arcane-stream-mock", StreamId: streamId, Completed: true},
+		}
+	}
+	requests := []v1.BackfillRequest{
+		newRequest("a-1", "a", 10*24*time.Hour),
+		newRequest("a-2", "a", 9*24*time.Hour),
+		newRequest("a-3", "a", time.Hour),
+		newRequest("b-1", "b", 10*24*time.Hour),
+	}
+
+	var names []string
+	for _, bfr := range expiredBackfillRequests(requests, 1, 7*24*time.Hour, now) {
+		names = append(names, bfr.Name)
+	}
+	require.Equal(t, []string{"a-1", "a-2"}, names)
+}
+
+func Test_BackfillOutcomeFromEvents(t *testing.T) {
+	event := func(reason, message string) corev1.Event {
+		return corev1.Event{Reason: reason, Message: message}
+	}
+	tests := []struct {
+		name      string
+		events    []corev1.Event
+		resolved  bool
+		succeeded bool
+		lastError string
+	}{
+		{
+			name:      "no events",
+			succeeded: true,
+		},
+		{
+			name:      "no terminal event",
+			events:    []corev1.Event{event("BackfillRequested", "Backfill was requested")},
+			succeeded: true,
+		},
+		{
+			name:      "completed",
+			events:    []corev1.Event{event("BackfillRequested", "Backfill was requested"), event(backfillSucceededReason, "")},
+			resolved:  true,
+			succeeded: true,
+		},
+		{
+			name:      "failed",
+			events:    []corev1.Event{event("BackoffLimitExceeded", " Job has reached the specified backoff limit "), event(backfillSucceededReason, "")},
+			resolved:  true,
+			lastError: "Job has reached the specified backoff limit",
+		},
+		{
+			name:      "completed, then the streaming job failed",
+			events:    []corev1.Event{event(backfillSucceededReason, ""), event("StreamingJobFailed", "The streaming job has failed")},
+			resolved:  true,
+			succeeded: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, succeeded, lastError := backfillOutcomeFromEvents(tt.events)
+			require.Equal(t, tt.resolved, resolved)
+			require.Equal(t, tt.succeeded, succeeded)
+			require.Equal(t, tt.lastError, lastError)
+		})
+	}
+}
@@ -5,7 +5,10 @@ import (
 
 	"github.com/SneaksAndData/arcane-operator/pkg/generated/clientset/versioned"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -15,7 +18,12 @@ var _ interfaces.ClientProvider = (*clientProvider)(nil)
 // It lazily initializes both a typed clientset for the Arcane Operator's custom resources and ensures the client
 // initialization order and caching of the clients.
 type clientProvider struct {
-	ConfigFlags *genericclioptions.ConfigFlags
+	ConfigFlags      *genericclioptions.ConfigFlags
+	ClientParameters *models.ClientParameters
+
+	configOnce sync.Once
+	config     *rest.Config
+	configErr  error
 
 	clientSetOnce sync.Once
 	clientSet     *versioned.Clientset
@@ -26,15 +34,16 @@ type clientProvider struct {
 	unstructuredErr    error
 }
 
-func NewClientProvider(configFlags *genericclioptions.ConfigFlags) interfaces.ClientProvider { // coverage-ignore (trivial)
+func NewClientProvider(configFlags *genericclioptions.ConfigFlags, clientParameters *models.ClientParameters) interfaces.ClientProvider { // coverage-ignore (trivial)
 	return &clientProvider{
-		ConfigFlags: configFlags,
+		ConfigFlags:      configFlags,
+		ClientParameters: clientParameters,
 	}
 }
 
 func (cp *clientProvider) ProvideClientSet() (*versioned.Clientset, error) { // coverage-ignore (trivial)
 	cp.clientSetOnce.Do(func() {
-		config, err := cp.ConfigFlags.ToRESTConfig()
+		config, err := cp.restConfig()
 		if err != nil {
 			cp.clientSetErr = err
 			return
@@ -51,7 +60,7 @@ func (cp *clientProvider) ProvideClientSet() (*versioned.Clientset, error) { //
 
 func (cp *clientProvider) ProvideUnstructuredClient() (client.Client, error) { // coverage-ignore (trivial)
 	cp.unstructuredOnce.Do(func() {
-		config, err := cp.ConfigFlags.ToRESTConfig()
+		config, err := cp.restConfig()
 		if err != nil {
 			cp.unstructuredErr = err
 			return
@@ -65,3 +74,25 @@ func (cp *clientProvider) ProvideUnstructuredClient() (client.Client, error) { /
 	})
 	return cp.unstructuredClient, cp.unstructuredErr
 }
+
+func (cp *clientProvider) ChunkSize() int64 { // coverage-ignore (trivial)
+	return max(cp.ClientParameters.ChunkSize, 0)
+}
+
+// restConfig returns the REST config of the kubeconfig, limited to the QPS and burst of the client parameters. Both
+// clients share the config and its rate limiter, so together they never exceed the limits.
+func (cp *clientProvider) restConfig() (*rest.Config, error) { // coverage-ignore (trivial)
+	cp.configOnce.Do(func() {
+		config, err := cp.ConfigFlags.ToRESTConfig()
+		if err != nil {
+			cp.configErr = err
+			return
+		}
+		config = rest.CopyConfig(config)
+		config.QPS = cp.ClientParameters.QPS
+		config.Burst = cp.ClientParameters.Burst
+		config.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(cp.ClientParameters.QPS, cp.ClientParameters.Burst)
+		cp.config = config
+	})
+	return cp.config, cp.configErr
+}
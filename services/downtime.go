@@ -2,6 +2,10 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
 
 	cmdinterfaces "github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
@@ -10,6 +14,10 @@ import (
 	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/services/publisher"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -21,6 +29,7 @@ type downtime struct {
 	clientProvider cmdinterfaces.ClientProvider
 	factory        *DowntimeProcessorFactory
 	executionQueue interfaces.ExecutionQueue
+	auditor        *auditor
 }
 
 // NewDowntimeService creates a new instance of the downtime, which provides downtime operations.
@@ -29,14 +38,61 @@ func NewDowntimeService(clientProvider cmdinterfaces.ClientProvider, factory *Do
 		clientProvider: clientProvider,
 		factory:        factory,
 		executionQueue: NewExecutionQueue(clientProvider),
+		auditor:        newAuditor(clientProvider),
 	}
 }
 
-// DeclareDowntime is a method that allows users to declare downtime for a stream or a list of streams, use the <key> parameter to identify the stream(s) to pause
+// DeclareDowntime is a method that allows users to declare downtime for a stream or a list of streams, use the <key> parameter to identify the stream(s) to pause.
+// Without a stream class, the streams of all stream classes are selected.
 func (s *downtime) DeclareDowntime(ctx context.Context, parameters *models.DowntimeDeclareParameters) error {
-	f := filter.NewUnsuspendedByNamePrefix(parameters.Prefix)
-	membersPublisher := publisher.NewStreamClassMembersPublisher(s.clientProvider, parameters.StreamClass, parameters.Namespace, f, &client.MatchingLabelsSelector{})
-	return s.executionQueue.ProcessQueue(ctx, s.factory.DowntimeDeclareProcessor(parameters), logging.Printer("suspended"), membersPublisher)
+	f, err := downtimeDeclareFilter(parameters)
+	if err != nil {
+		return err
+	}
+	selector, err := labels.Parse(parameters.LabelSelector)
+	if err != nil {
+		return fmt.Errorf("error parsing label selector: %w", err)
+	}
+	var queuePublisher interfaces.QueuePublisher
+	if parameters.StreamClass == "" {
+		queuePublisher = publisher.NewAllStreamDefinitionsPublisher(s.clientProvider, parameters.Namespace, f, &client.MatchingLabelsSelector{Selector: selector})
+	} else {
+		queuePublisher = publisher.NewStreamClassMembersPublisher(s.clientProvider, parameters.StreamClass, parameters.Namespace, f, &client.MatchingLabelsSelector{Selector: selector})
+	}
+	options := interfaces.QueueOptions{DryRun: parameters.DryRun, MaxAttempts: parameters.Queue.MaxAttempts, Concurrency: parameters.Queue.Concurrency, PrintSummary: true}
+	printer, err := logging.NewPrinter(parameters.Output, parameters.DryRun.Operation("suspended"))
+	if err != nil {
+		return err
+	}
+	return s.executionQueue.ProcessQueue(ctx, s.factory.DowntimeDeclareProcessor(parameters, s.auditor.NewRecord(ctx, operationDowntimeDeclare, parameters.Audit, parameters.DryRun)), printer, queuePublisher, options)
+}
+
+// downtimeDeclareFilter selects the streams that are not suspended or already in downtime, and match all selection
+// criteria of the parameters. Streams suspended outside a downtime are skipped, so stopping the downtime does not resume
+// streams that were suspended before.
+func downtimeDeclareFilter(parameters *models.DowntimeDeclareParameters) (interfaces.ObjectFilter, error) {
+	filters := []interfaces.ObjectFilter{filter.NewAnyOf(filter.NewUnsuspended(), filter.NewInDowntime())}
+	if parameters.Prefix != "" {
+		filters = append(filters, filter.NewByNamePrefix(parameters.Prefix))
+	}
+	if parameters.Regex != "" {
+		pattern, err := regexp.Compile(parameters.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing regular expression: %w", err)
+		}
+		filters = append(filters, filter.NewByNameRegex(pattern))
+	}
+	if parameters.Exclude != "" {
+		pattern, err := regexp.Compile(parameters.Exclude)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing exclude regular expression: %w", err)
+		}
+		filters = append(filters, filter.NewNot(filter.NewByNameRegex(pattern)))
+	}
+	if len(parameters.StreamIds) > 0 {
+		filters = append(filters, filter.NewByNames(parameters.StreamIds))
+	}
+	return filter.NewAllOf(filters...), nil
 }
 
 // StopDowntime is a method that allows users to stop downtime for a stream or a list of streams, use the <key> parameter to identify the stream(s) to resume
@@ -46,8 +102,59 @@ func (s *downtime) StopDowntime(ctx context.Context, parameters *models.Downtime
 	if err != nil {
 		return err
 	}
-	membersPublisher := publisher.NewStreamClassMembersPublisher(s.clientProvider, parameters.StreamClass, "", f, selector)
-	return s.executionQueue.ProcessQueue(ctx, s.factory.DowntimeStopProcessor(parameters), logging.Printer("started"), membersPublisher)
+	var queuePublisher interfaces.QueuePublisher
+	if parameters.StreamClass == "" {
+		queuePublisher = publisher.NewAllStreamDefinitionsPublisher(s.clientProvider, "", f, selector)
+	} else {
+		queuePublisher = publisher.NewStreamClassMembersPublisher(s.clientProvider, parameters.StreamClass, "", f, selector)
+	}
+	options := interfaces.QueueOptions{DryRun: parameters.DryRun, MaxAttempts: parameters.Queue.MaxAttempts, Concurrency: parameters.Queue.Concurrency, PrintSummary: true}
+	printer, err := downtimeStopPrinter(parameters.Output, parameters.DryRun)
+	if err != nil {
+		return err
+	}
+	return s.executionQueue.ProcessQueue(ctx, s.factory.DowntimeStopProcessor(parameters, s.auditor.NewRecord(ctx, operationDowntimeStop, parameters.Audit, parameters.DryRun)), printer, queuePublisher, options)
+}
+
+// downtimeStopPrinter prints the resumed streams as started, and the streams that stay in downtime for other keys as
+// kept in downtime.
+func downtimeStopPrinter(output string, dryRun models.DryRunStrategy) (printers.ResourcePrinter, error) {
+	started, err := logging.NewPrinter(output, dryRun.Operation("started"))
+	if err != nil {
+		return nil, err
+	}
+	kept, err := logging.NewPrinter(output, dryRun.Operation("kept in downtime"))
+	if err != nil { // coverage-ignore (validated by the first printer)
+		return nil, err
+	}
+	return printers.ResourcePrinterFunc(func(object runtime.Object, w io.Writer) error {
+		if stream, ok := object.(*unstructured.Unstructured); ok && len(filter.DowntimeKeys(stream)) > 0 {
+			return kept.PrintObj(object, w)
+		}
+		return started.PrintObj(object, w)
+	}), nil
+}
+
+// ExpireDowntimes is a method that allows users to drop the expired downtime keys and resume the streams without a remaining key
+func (s *downtime) ExpireDowntimes(ctx context.Context, parameters *models.DowntimeExpireParameters) error {
+	// The processor checks the expiry of every stream again, the filter only skips reading streams that did not expire
+	f := filter.NewByExpiredDowntime(time.Now())
+	selector, err := s.streamsInDowntimeSelector()
+	if err != nil {
+		return err
+	}
+	var queuePublisher interfaces.QueuePublisher
+	if parameters.StreamClass == "" {
+		queuePublisher = publisher.NewAllStreamDefinitionsPublisher(s.clientProvider, "", f, selector)
+	} else {
+		queuePublisher = publisher.NewStreamClassMembersPublisher(s.clientProvider, parameters.StreamClass, "", f, selector)
+	}
+	options := interfaces.QueueOptions{DryRun: parameters.DryRun, MaxAttempts: parameters.Queue.MaxAttempts, Concurrency: parameters.Queue.Concurrency, PrintSummary: true}
+	printer, err := logging.NewPrinter(parameters.Output, parameters.DryRun.Operation("started"))
+	if err != nil {
+		return err
+	}
+	return s.executionQueue.ProcessQueue(ctx, s.factory.DowntimeExpireProcessor(s.auditor.NewRecord(ctx, operationDowntimeExpire, parameters.Audit, parameters.DryRun)), printer, queuePublisher, options)
 }
 
 func (s *downtime) GetSummary(ctx context.Context, parameters *models.DowntimeSummaryParameters) (cmdinterfaces.DowntimeSummary, error) {
@@ -57,18 +164,18 @@ func (s *downtime) GetSummary(ctx context.Context, parameters *models.DowntimeSu
 		return nil, err
 	}
 	if parameters.StreamClass == "" {
-		queuePublisher = publisher.NewAllStreamDefinitionsPublisher(s.clientProvider, selector)
+		queuePublisher = publisher.NewAllStreamDefinitionsPublisher(s.clientProvider, "", filter.NewAllowAll(), selector)
 	} else {
 		queuePublisher = publisher.NewStreamClassMembersPublisher(s.clientProvider, parameters.StreamClass, "", filter.NewAllowAll(), selector)
 	}
 
 	processor := s.factory.DowntimeSummarizationProcessor()
-	err = s.executionQueue.ProcessQueue(ctx, processor, logging.Printer(""), queuePublisher)
+	err = s.executionQueue.ProcessQueue(ctx, processor, logging.Printer(""), queuePublisher, interfaces.QueueOptions{})
 	if err != nil { // coverage-ignore
 		return nil, err
 	}
 
-	return NewDowntimeSummary(processor.Summary, processor.Durations), nil
+	return NewDowntimeSummary(processor.Summary, processor.Durations, processor.Expiries), nil
 }
 
 func (s *downtime) streamsInDowntimeSelector() (*client.MatchingLabelsSelector, error) {
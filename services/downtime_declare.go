@@ -2,57 +2,92 @@ package services
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	v1 "github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
 	"github.com/SneaksAndData/arcane-operator/services/controllers/contracts"
-	"github.com/sneaksAndData/kubectl-plugin-arcane/logging"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/filter"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/types"
 )
 
 var _ interfaces.UnstructuredProcessor = (*downtimeDeclareProcessor)(nil)
 
 type downtimeDeclareProcessor struct {
 	key    string
-	reader interfaces.UnstructuredReader
+	expiry time.Time
+	audit  AuditRecord
 }
 
-func (s *downtimeDeclareProcessor) Process(ctx context.Context, def types.NamespacedName, class *v1.StreamClass) (*unstructured.Unstructured, bool, error) {
-	stream, err := s.reader.Read(ctx, class, def)
+func (s *downtimeDeclareProcessor) Process(_ context.Context, stream *unstructured.Unstructured, class *v1.StreamClass) (*unstructured.Unstructured, bool, error) {
+	definition, err := contracts.FromUnstructured(stream)
 	if err != nil {
 		return nil, false, err
 	}
 
-	labels := stream.GetLabels()
+	// A stream can be in downtime for several keys at once, it is only resumed once the downtimes of all keys are stopped
+	keys := filter.DowntimeKeys(stream)
+	entries := downtimeEntries(stream)
+	entry, declared := entries[s.key]
+	if declared {
+		// Declaring the downtime of a key again keeps its begin and only extends its expiry
+		expiry := s.extendedExpiry(entry.Expiry)
+		if definition.Suspended() && sameExpiry(expiry, entry.Expiry) {
+			return nil, false, nil
+		}
+		entry.Expiry = expiry
+	} else {
+		keys = append(keys, s.key)
+		entry = downtimeEntry{Begin: time.Now().UTC().Truncate(time.Second), Expiry: s.extendedExpiry(nil)}
+	}
+	entries[s.key] = entry
 
-	if existingKey, exists := labels[interfaces.DowntimeLabelKey]; exists && existingKey != s.key {
-		logging.LogError(stream, "already has a different downtime key", err)
-		return nil, false, nil // Skip items that already have a different downtime key
+	err = setDowntimeEntries(stream, keys, entries)
+	if err != nil { // coverage-ignore
+		return nil, false, err
 	}
+	s.audit.Apply(stream)
 
+	err = definition.SetSuspended(true)
+	if err != nil {
+		return nil, false, err
+	}
+	return definition.ToUnstructured(), true, nil
+}
+
+// extendedExpiry returns the expiry of the downtime of the key after it is declared with the expiry of the processor.
+// A new downtime without an expiry never expires, while the expiry of a downtime declared again is only moved later.
+func (s *downtimeDeclareProcessor) extendedExpiry(current *time.Time) *time.Time {
+	if s.expiry.IsZero() || (current != nil && !s.expiry.After(*current)) {
+		return current
+	}
+	expiry := s.expiry.UTC()
+	return &expiry
+}
+
+// sameExpiry returns true if both downtimes never expire, or both expire at the same time.
+func sameExpiry(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
+
+// setDowntimeKeys stores the downtime keys of the stream, the first key in the DowntimeLabelKey label and all of them
+// in the DowntimeKeysAnnotationKey annotation.
+func setDowntimeKeys(stream *unstructured.Unstructured, keys []string) {
+	labels := stream.GetLabels()
 	if labels == nil {
 		labels = make(map[string]string)
 	}
-
-	labels[interfaces.DowntimeLabelKey] = s.key
+	labels[interfaces.DowntimeLabelKey] = keys[0]
 	stream.SetLabels(labels)
 
 	annotations := stream.GetAnnotations()
 	if annotations == nil {
 		annotations = make(map[string]string)
 	}
-	annotations[interfaces.DowntimeBeginAnnotationKey] = time.Now().UTC().Format(time.RFC3339)
+	annotations[interfaces.DowntimeKeysAnnotationKey] = strings.Join(keys, ",")
 	stream.SetAnnotations(annotations)
-
-	definition, err := contracts.FromUnstructured(stream)
-	if err != nil {
-		return nil, false, err
-	}
-	err = definition.SetSuspended(true)
-	if err != nil {
-		return nil, false, err
-	}
-	return definition.ToUnstructured(), true, nil
 }
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/sneaksAndData/kubectl-plugin-arcane/logging"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/filter"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// downtimeEntry is the downtime of a stream for a single downtime key.
+type downtimeEntry struct {
+	Begin  time.Time  `json:"begin"`
+	Expiry *time.Time `json:"expiry,omitempty"` // The downtime of the key does not expire if nil.
+}
+
+// downtimeEntries returns the downtime of the stream per downtime key. Streams declared in downtime before the downtimes
+// were recorded per key share the begin and the expiry of the stream between all their keys.
+func downtimeEntries(stream *unstructured.Unstructured) map[string]downtimeEntry {
+	keys := filter.DowntimeKeys(stream)
+	entries := make(map[string]downtimeEntry, len(keys))
+	if value, ok := stream.GetAnnotations()[interfaces.DowntimesAnnotationKey]; ok {
+		err := json.Unmarshal([]byte(value), &entries)
+		if err != nil {
+			logging.LogError(stream, "to parse the downtimes per key, using the downtime of the stream", err)
+			entries = make(map[string]downtimeEntry, len(keys))
+		}
+	}
+
+	shared := downtimeEntry{}
+	begin, err := time.ParseInLocation(time.RFC3339, stream.GetAnnotations()[interfaces.DowntimeBeginAnnotationKey], time.UTC)
+	if err == nil {
+		shared.Begin = begin
+	}
+	expiry, hasExpiry, err := downtimeExpiry(stream)
+	if err != nil {
+		logging.LogError(stream, "to parse downtime expiry, the downtime will not expire", err)
+	}
+	if hasExpiry {
+		shared.Expiry = &expiry
+	}
+
+	for key := range entries {
+		if !slices.Contains(keys, key) {
+			delete(entries, key)
+		}
+	}
+	for _, key := range keys {
+		if _, ok := entries[key]; !ok {
+			entries[key] = shared
+		}
+	}
+	return entries
+}
+
+// setDowntimeEntries stores the downtime keys of the stream together with the downtime per key. The begin of the
+// downtime of the stream is the earliest begin of its keys. The stream expires when the downtimes of all its keys have
+// expired, so its expiry is the latest expiry of its keys, and it never expires if any of its keys does not expire.
+func setDowntimeEntries(stream *unstructured.Unstructured, keys []string, entries map[string]downtimeEntry) error {
+	setDowntimeKeys(stream, keys)
+
+	value, err := json.Marshal(entries)
+	if err != nil { // coverage-ignore
+		return err
+	}
+
+	var begin, expiry time.Time
+	expires := true
+	for _, entry := range entries {
+		if !entry.Begin.IsZero() && (begin.IsZero() || entry.Begin.Before(begin)) {
+			begin = entry.Begin
+		}
+		if entry.Expiry == nil {
+			expires = false
+		} else if entry.Expiry.After(expiry) {
+			expiry = *entry.Expiry
+		}
+	}
+
+	annotations := stream.GetAnnotations()
+	annotations[interfaces.DowntimesAnnotationKey] = string(value)
+	if !begin.IsZero() {
+		annotations[interfaces.DowntimeBeginAnnotationKey] = begin.UTC().Format(time.RFC3339)
+	}
+	if expires {
+		annotations[interfaces.DowntimeExpiryAnnotationKey] = expiry.UTC().Format(time.RFC3339)
+	} else {
+		delete(annotations, interfaces.DowntimeExpiryAnnotationKey)
+	}
+	stream.SetAnnotations(annotations)
+	return nil
+}
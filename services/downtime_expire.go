@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	v1 "github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/errors"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/filter"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ interfaces.UnstructuredProcessor = (*downtimeExpireProcessor)(nil)
+
+// downtimeExpireProcessor drops the downtime keys of each stream whose downtime has passed its expiry, and ends the
+// downtime of the stream once no key remains. Keys without an expiry never expire.
+type downtimeExpireProcessor struct {
+	audit AuditRecord
+}
+
+func (s downtimeExpireProcessor) Process(_ context.Context, stream *unstructured.Unstructured, class *v1.StreamClass) (*unstructured.Unstructured, bool, error) {
+	if _, inDowntime := stream.GetLabels()[interfaces.DowntimeLabelKey]; !inDowntime {
+		return nil, false, nil // The downtime was stopped in the meantime
+	}
+
+	_, _, err := downtimeExpiry(stream)
+	if err != nil {
+		return nil, false, errors.NewSkippedError(fmt.Sprintf("invalid downtime expiry: %v", err))
+	}
+
+	now := time.Now()
+	entries := downtimeEntries(stream)
+	keys := filter.DowntimeKeys(stream)
+	remaining := slices.DeleteFunc(slices.Clone(keys), func(key string) bool {
+		expiry := entries[key].Expiry
+		return expiry != nil && !now.Before(*expiry)
+	})
+	if len(remaining) == len(keys) {
+		return nil, false, nil
+	}
+	if len(remaining) == 0 {
+		return endDowntime(stream, s.audit)
+	}
+
+	// The stream stays suspended until the downtimes of the remaining keys expire or are stopped
+	for _, key := range keys {
+		if !slices.Contains(remaining, key) {
+			delete(entries, key)
+		}
+	}
+	err = setDowntimeEntries(stream, remaining, entries)
+	if err != nil { // coverage-ignore
+		return nil, false, err
+	}
+	s.audit.Apply(stream)
+	return stream, true, nil
+}
+
+// downtimeExpiry returns the expiry of the downtime of the stream, and whether the downtime has an expiry.
+func downtimeExpiry(stream *unstructured.Unstructured) (time.Time, bool, error) {
+	value, ok := stream.GetAnnotations()[interfaces.DowntimeExpiryAnnotationKey]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	expiry, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return expiry, true, nil
+}
@@ -5,30 +5,33 @@ import (
 	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
 )
 
-type DowntimeProcessorFactory struct {
-	reader interfaces.UnstructuredReader
-}
+type DowntimeProcessorFactory struct{}
 
-func NewDowntimeProcessorFactory(reader interfaces.UnstructuredReader) *DowntimeProcessorFactory {
-	return &DowntimeProcessorFactory{
-		reader: reader,
-	}
+func NewDowntimeProcessorFactory() *DowntimeProcessorFactory {
+	return &DowntimeProcessorFactory{}
 }
 
-func (s DowntimeProcessorFactory) DowntimeDeclareProcessor(parameters *models.DowntimeDeclareParameters) interfaces.UnstructuredProcessor {
+func (s DowntimeProcessorFactory) DowntimeDeclareProcessor(parameters *models.DowntimeDeclareParameters, audit AuditRecord) interfaces.UnstructuredProcessor {
 	return &downtimeDeclareProcessor{
 		key:    parameters.DowntimeKey,
-		reader: s.reader,
+		expiry: parameters.Expiry,
+		audit:  audit,
 	}
 }
 
-func (s DowntimeProcessorFactory) DowntimeStopProcessor(parameters *models.DowntimeStopParameters) interfaces.UnstructuredProcessor {
+func (s DowntimeProcessorFactory) DowntimeStopProcessor(parameters *models.DowntimeStopParameters, audit AuditRecord) interfaces.UnstructuredProcessor {
 	return &downtimeStopProcessor{
-		key:    parameters.DowntimeKey,
-		reader: s.reader,
+		key:   parameters.DowntimeKey,
+		audit: audit,
+	}
+}
+
+func (s DowntimeProcessorFactory) DowntimeExpireProcessor(audit AuditRecord) interfaces.UnstructuredProcessor {
+	return &downtimeExpireProcessor{
+		audit: audit,
 	}
 }
 
 func (s DowntimeProcessorFactory) DowntimeSummarizationProcessor() *DowntimeSummarizationProcessor {
-	return NewDowntimeSummarizationProcessor(s.reader)
+	return NewDowntimeSummarizationProcessor()
 }
@@ -2,40 +2,58 @@ package services
 
 import (
 	"context"
+	"slices"
 
 	v1 "github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
 	"github.com/SneaksAndData/arcane-operator/services/controllers/contracts"
-	"github.com/sneaksAndData/kubectl-plugin-arcane/logging"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/errors"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/filter"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/types"
 )
 
 var _ interfaces.UnstructuredProcessor = (*downtimeStopProcessor)(nil)
 
 type downtimeStopProcessor struct {
-	key    string
-	reader interfaces.UnstructuredReader
+	key   string
+	audit AuditRecord
 }
 
-func (s downtimeStopProcessor) Process(ctx context.Context, def types.NamespacedName, class *v1.StreamClass) (*unstructured.Unstructured, bool, error) {
-	stream, err := s.reader.Read(ctx, class, def)
+func (s downtimeStopProcessor) Process(_ context.Context, stream *unstructured.Unstructured, class *v1.StreamClass) (*unstructured.Unstructured, bool, error) {
+	keys := filter.DowntimeKeys(stream)
+	if !slices.Contains(keys, s.key) {
+		return nil, false, errors.NewSkippedError("has a different downtime key") // Skip items that don't match the downtime key
+	}
+
+	keys = slices.DeleteFunc(keys, func(key string) bool { return key == s.key })
+	if len(keys) == 0 {
+		return endDowntime(stream, s.audit)
+	}
+
+	// The stream stays suspended until the downtimes of the remaining keys are stopped, with their begin and expiry
+	entries := downtimeEntries(stream)
+	delete(entries, s.key)
+	err := setDowntimeEntries(stream, keys, entries)
 	if err != nil { // coverage-ignore
 		return nil, false, err
 	}
+	s.audit.Apply(stream)
+	return stream, true, nil
+}
 
+// endDowntime removes the downtime keys, begin and expiry of the stream and resumes it.
+func endDowntime(stream *unstructured.Unstructured, audit AuditRecord) (*unstructured.Unstructured, bool, error) {
 	labels := stream.GetLabels()
-	if labels[interfaces.DowntimeLabelKey] != s.key {
-		logging.LogError(stream, "has a different downtime key, skipping", err)
-		return nil, false, nil // Skip items that don't match the downtime key
-	}
-
 	delete(labels, interfaces.DowntimeLabelKey)
 	stream.SetLabels(labels)
 
 	annotations := stream.GetAnnotations()
+	delete(annotations, interfaces.DowntimeKeysAnnotationKey)
+	delete(annotations, interfaces.DowntimesAnnotationKey)
 	delete(annotations, interfaces.DowntimeBeginAnnotationKey)
+	delete(annotations, interfaces.DowntimeExpiryAnnotationKey)
 	stream.SetAnnotations(annotations)
+	audit.Apply(stream)
 
 	definition, err := contracts.FromUnstructured(stream)
 	if err != nil { // coverage-ignore
@@ -3,65 +3,67 @@ package services
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	v1 "github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/logging"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/filter"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/types"
 )
 
 var _ interfaces.UnstructuredProcessor = (*DowntimeSummarizationProcessor)(nil)
 
+// DowntimeSummarizationProcessor groups the streams in downtime by downtime key, keeping the earliest begin and the
+// earliest expiry of each key for `downtime list`.
 type DowntimeSummarizationProcessor struct {
-	reader    interfaces.UnstructuredReader
 	Summary   map[string][]string
 	Durations map[string]time.Time
+	Expiries  map[string]time.Time
+
+	lock sync.Mutex
 }
 
-func NewDowntimeSummarizationProcessor(reader interfaces.UnstructuredReader) *DowntimeSummarizationProcessor {
+func NewDowntimeSummarizationProcessor() *DowntimeSummarizationProcessor {
 	return &DowntimeSummarizationProcessor{
-		reader:    reader,
 		Summary:   make(map[string][]string),
 		Durations: make(map[string]time.Time),
+		Expiries:  make(map[string]time.Time),
 	}
 }
 
-func (s DowntimeSummarizationProcessor) Process(ctx context.Context, def types.NamespacedName, class *v1.StreamClass) (*unstructured.Unstructured, bool, error) {
-	stream, err := s.reader.Read(ctx, class, def)
-	if err != nil { // coverage-ignore
-		return nil, false, err
+func (s *DowntimeSummarizationProcessor) Process(_ context.Context, stream *unstructured.Unstructured, class *v1.StreamClass) (*unstructured.Unstructured, bool, error) {
+	keys := filter.DowntimeKeys(stream)
+	if len(keys) == 0 { // coverage-ignore
+		return nil, false, nil
 	}
 
-	labels := stream.GetLabels()
+	streamId := fmt.Sprintf("%s/%s", stream.GetNamespace(), stream.GetName())
 
-	if labels == nil { // coverage-ignore
-		return nil, false, nil
-	}
+	// A stream in downtime for several keys is counted for each of them, with the begin and the expiry of the key
+	entries := downtimeEntries(stream)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, label := range keys {
+		s.Summary[label] = append(s.Summary[label], streamId)
 
-	label := labels[interfaces.DowntimeLabelKey]
+		entry := entries[label]
+		begin := entry.Begin
+		if begin.IsZero() {
+			logging.LogError(stream, "to parse downtime start date for stream, using the current time", fmt.Errorf("no begin recorded for downtime key %s", label))
+			begin = time.Now().UTC()
+		}
 
-	var ms time.Time
-	annotations := stream.GetAnnotations()
-	if annotations != nil {
-		startDate := annotations[interfaces.DowntimeBeginAnnotationKey]
-		ms, err = time.ParseInLocation(time.RFC3339, startDate, time.UTC)
-		if err != nil {
-			logging.LogError(stream, "to parse downtime start date for stream, skipping", err)
-			ms = time.Now().UTC()
+		// We want to keep the earliest downtime start time for each key
+		if prev, ok := s.Durations[label]; !ok || begin.Before(prev) {
+			s.Durations[label] = begin
 		}
-	} else {
-		logging.LogError(stream, "to parse downtime start date for stream, skipping", err)
-		ms = time.Now().UTC()
-	}
-	// Go 1.17+:
-	streamId := fmt.Sprintf("%s/%s", stream.GetNamespace(), stream.GetName())
-	s.Summary[label] = append(s.Summary[label], streamId)
 
-	// We want to keep the earliest downtime start time for each key
-	if prev, ok := s.Durations[label]; !ok || ms.Before(prev) {
-		s.Durations[label] = ms
+		// We want to keep the earliest downtime expiry for each key, as the first streams are resumed then
+		if prev, ok := s.Expiries[label]; entry.Expiry != nil && (!ok || entry.Expiry.Before(prev)) {
+			s.Expiries[label] = *entry.Expiry
+		}
 	}
 
 	// We return nil here because we don't want to modify the original object, we just want to update our summaries
@@ -1,10 +1,20 @@
 package services
 
 import (
+	"sort"
 	"time"
 
 	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// downtimeAPIVersion and downtimeKind identify the objects describing a downtime key in structured output.
+// Downtimes are not stored as resources in the cluster, these objects only exist in the plugin output.
+const (
+	downtimeAPIVersion = "arcane.sneaksanddata.com/v1"
+	downtimeKind       = "Downtime"
 )
 
 var _ interfaces.DowntimeSummary = (*DowntimeSummary)(nil)
@@ -12,10 +22,11 @@ var _ interfaces.DowntimeSummary = (*DowntimeSummary)(nil)
 type DowntimeSummary struct {
 	groupedByKey map[string][]string
 	durations    map[string]time.Time
+	expiries     map[string]time.Time
 }
 
-func NewDowntimeSummary(counts map[string][]string, durations map[string]time.Time) *DowntimeSummary {
-	return &DowntimeSummary{groupedByKey: counts, durations: durations}
+func NewDowntimeSummary(counts map[string][]string, durations map[string]time.Time, expiries map[string]time.Time) *DowntimeSummary {
+	return &DowntimeSummary{groupedByKey: counts, durations: durations, expiries: expiries}
 }
 
 func (d *DowntimeSummary) Counts() *metav1.Table { // coverage-ignore (tested in integration tests)
@@ -28,6 +39,7 @@ func (d *DowntimeSummary) Counts() *metav1.Table { // coverage-ignore (tested in
 			{Name: "Name", Type: "string"},
 			{Name: "Count", Type: "integer"},
 			{Name: "Duration", Type: "string"},
+			{Name: "Remaining", Type: "string"},
 		},
 	}
 
@@ -37,6 +49,7 @@ func (d *DowntimeSummary) Counts() *metav1.Table { // coverage-ignore (tested in
 				key,
 				len(streams),
 				time.Since(d.durations[key]).String(),
+				d.remaining(key),
 			},
 		}
 		table.Rows = append(table.Rows, row)
@@ -45,6 +58,20 @@ func (d *DowntimeSummary) Counts() *metav1.Table { // coverage-ignore (tested in
 	return table
 }
 
+// remaining returns the time until the earliest expiry of the downtime key, "expired" if it has passed, or <none> if
+// the downtime does not expire.
+func (d *DowntimeSummary) remaining(key string) string {
+	expiry, ok := d.expiries[key]
+	switch {
+	case !ok:
+		return "<none>"
+	case !time.Now().Before(expiry):
+		return "expired"
+	default:
+		return duration.HumanDuration(time.Until(expiry))
+	}
+}
+
 func (d *DowntimeSummary) CountsRaw() map[string]int {
 	counts := make(map[string]int)
 	for key, items := range d.groupedByKey {
@@ -84,3 +111,38 @@ func (d *DowntimeSummary) Details() *metav1.Table { // coverage-ignore (tested i
 func (d *DowntimeSummary) DetailsRaw() map[string][]string {
 	return d.groupedByKey
 }
+
+func (d *DowntimeSummary) Objects() *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetAPIVersion("v1")
+	list.SetKind("List")
+
+	keys := make([]string, 0, len(d.groupedByKey))
+	for key := range d.groupedByKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	counts := d.CountsRaw()
+	for _, key := range keys {
+		streams := make([]interface{}, 0, len(d.groupedByKey[key]))
+		for _, stream := range d.groupedByKey[key] {
+			streams = append(streams, stream)
+		}
+
+		item := unstructured.Unstructured{Object: map[string]interface{}{
+			"count":   int64(counts[key]),
+			"since":   d.durations[key].UTC().Format(time.RFC3339),
+			"streams": streams,
+		}}
+		if expiry, ok := d.expiries[key]; ok {
+			item.Object["expiry"] = expiry.UTC().Format(time.RFC3339)
+		}
+		item.SetAPIVersion(downtimeAPIVersion)
+		item.SetKind(downtimeKind)
+		item.SetName(key)
+		list.Items = append(list.Items, item)
+	}
+
+	return list
+}
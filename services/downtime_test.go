@@ -1,8 +1,10 @@
 package services
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/sneaksAndData/kubectl-plugin-arcane/tests/helpers"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -57,6 +60,339 @@ func TestDowntime_DeclareDowntime(t *testing.T) {
 	require.Contains(t, s.Annotations, interfaces.DowntimeBeginAnnotationKey)
 }
 
+func TestDowntime_DeclareDowntime_DryRun(t *testing.T) {
+	// Arrange
+	pattern := "declare-downtime-dry-run-test-"
+
+	name := helpers.NewTestStream(t, clientSet, func(def *mockv1.TestStreamDefinition) {
+		def.Spec.RunDuration = "5s"
+		def.Spec.Suspended = false
+		def.Spec.ShouldFail = false
+		def.GenerateName = pattern
+	})
+	require.NotEmpty(t, name)
+
+	err := waitForPhase(t, name, streamapis.Running)
+	require.NoError(t, err)
+
+	downtimeService := createDowntimeService(t)
+
+	// Act
+	err = downtimeService.DeclareDowntime(t.Context(), &models.DowntimeDeclareParameters{
+		StreamClass: "arcane-stream-mock",
+		DowntimeKey: "maintenance-window-dry-run",
+		Prefix:      pattern,
+		DryRun:      models.DryRunServer,
+	})
+	require.NoError(t, err)
+
+	// Assert
+	s, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotContains(t, s.Labels, interfaces.DowntimeLabelKey)
+	require.False(t, s.Spec.Suspended)
+}
+
+func TestDowntime_DeclareDowntime_Selection(t *testing.T) {
+	// Arrange
+	pattern := "declare-downtime-selection-test-"
+	streams := map[string]string{}
+	for _, team := range []string{"sales", "sales", "finance"} {
+		name := helpers.NewTestStream(t, clientSet, func(def *mockv1.TestStreamDefinition) {
+			def.Labels = map[string]string{"team": team}
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = false
+			def.Spec.ShouldFail = false
+			def.GenerateName = pattern
+		})
+		require.NotEmpty(t, name)
+		streams[name] = team
+	}
+
+	var excluded string
+	for name, team := range streams {
+		if team == "sales" {
+			excluded = name
+			break
+		}
+	}
+
+	downtimeService := createDowntimeService(t)
+
+	// Act
+	err := downtimeService.DeclareDowntime(t.Context(), &models.DowntimeDeclareParameters{
+		StreamClass:   "arcane-stream-mock",
+		DowntimeKey:   "maintenance-window-selection",
+		LabelSelector: "team=sales",
+		Regex:         "^" + pattern,
+		Exclude:       "^" + excluded + "$",
+	})
+	require.NoError(t, err)
+
+	// Assert
+	for name, team := range streams {
+		s, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+		require.NoError(t, err)
+		if team == "sales" && name != excluded {
+			require.Equal(t, "maintenance-window-selection", s.Labels[interfaces.DowntimeLabelKey])
+			require.True(t, s.Spec.Suspended)
+		} else {
+			require.NotContains(t, s.Labels, interfaces.DowntimeLabelKey)
+			require.False(t, s.Spec.Suspended)
+		}
+	}
+}
+
+func TestDowntime_DeclareDowntime_AllStreamClasses(t *testing.T) {
+	// Arrange
+	pattern := "declare-downtime-all-classes-test-"
+
+	name := helpers.NewTestStream(t, clientSet, func(def *mockv1.TestStreamDefinition) {
+		def.Spec.RunDuration = "5s"
+		def.Spec.Suspended = false
+		def.Spec.ShouldFail = false
+		def.GenerateName = pattern
+	})
+	require.NotEmpty(t, name)
+
+	downtimeService := createDowntimeService(t)
+
+	// Act
+	err := downtimeService.DeclareDowntime(t.Context(), &models.DowntimeDeclareParameters{
+		DowntimeKey: "maintenance-window-all-classes",
+		Prefix:      pattern,
+	})
+	require.NoError(t, err)
+
+	s, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "maintenance-window-all-classes", s.Labels[interfaces.DowntimeLabelKey])
+	require.True(t, s.Spec.Suspended)
+
+	err = downtimeService.StopDowntime(t.Context(), &models.DowntimeStopParameters{
+		DowntimeKey: "maintenance-window-all-classes",
+	})
+	require.NoError(t, err)
+
+	// Assert
+	s, err = clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotContains(t, s.Labels, interfaces.DowntimeLabelKey)
+	require.False(t, s.Spec.Suspended)
+}
+
+func TestDowntime_OverlappingDowntimes(t *testing.T) {
+	// Arrange
+	pattern := "overlapping-downtime-test-"
+
+	name := helpers.NewTestStream(t, clientSet, func(def *mockv1.TestStreamDefinition) {
+		def.Spec.RunDuration = "5s"
+		def.Spec.Suspended = false
+		def.Spec.ShouldFail = false
+		def.GenerateName = pattern
+	})
+	require.NotEmpty(t, name)
+
+	downtimeService := createDowntimeService(t)
+	for _, key := range []string{"overlapping-window-1", "overlapping-window-2"} {
+		err := downtimeService.DeclareDowntime(t.Context(), &models.DowntimeDeclareParameters{
+			StreamClass: "arcane-stream-mock",
+			DowntimeKey: key,
+			Prefix:      pattern,
+		})
+		require.NoError(t, err)
+	}
+
+	s, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "overlapping-window-1,overlapping-window-2", s.Annotations[interfaces.DowntimeKeysAnnotationKey])
+
+	// Act
+	err = downtimeService.StopDowntime(t.Context(), &models.DowntimeStopParameters{
+		StreamClass: "arcane-stream-mock",
+		DowntimeKey: "overlapping-window-1",
+	})
+	require.NoError(t, err)
+
+	// Assert
+	s, err = clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.True(t, s.Spec.Suspended)
+	require.Equal(t, "overlapping-window-2", s.Labels[interfaces.DowntimeLabelKey])
+	require.Equal(t, "overlapping-window-2", s.Annotations[interfaces.DowntimeKeysAnnotationKey])
+
+	err = downtimeService.StopDowntime(t.Context(), &models.DowntimeStopParameters{
+		StreamClass: "arcane-stream-mock",
+		DowntimeKey: "overlapping-window-2",
+	})
+	require.NoError(t, err)
+
+	s, err = clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.False(t, s.Spec.Suspended)
+	require.NotContains(t, s.Labels, interfaces.DowntimeLabelKey)
+	require.NotContains(t, s.Annotations, interfaces.DowntimeKeysAnnotationKey)
+}
+
+func TestDowntime_DeclareDowntime_Again(t *testing.T) {
+	// Arrange
+	pattern := "declare-downtime-again-test-"
+	begin := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	expiry := time.Now().Add(2 * time.Hour).UTC().Format(time.RFC3339)
+
+	name := helpers.NewTestStream(t, clientSet, func(def *mockv1.TestStreamDefinition) {
+		def.Labels = map[string]string{
+			interfaces.DowntimeLabelKey: "maintenance-window-again",
+		}
+		def.Annotations = map[string]string{
+			interfaces.DowntimeBeginAnnotationKey:  begin,
+			interfaces.DowntimeExpiryAnnotationKey: expiry,
+		}
+		def.Spec.RunDuration = "5s"
+		def.Spec.Suspended = true
+		def.Spec.ShouldFail = false
+		def.GenerateName = pattern
+	})
+	require.NotEmpty(t, name)
+
+	downtimeService := createDowntimeService(t)
+
+	// Act
+	err := downtimeService.DeclareDowntime(t.Context(), &models.DowntimeDeclareParameters{
+		StreamClass: "arcane-stream-mock",
+		DowntimeKey: "maintenance-window-again",
+		Prefix:      pattern,
+	})
+	require.NoError(t, err)
+
+	// Assert
+	s, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.True(t, s.Spec.Suspended)
+	require.Equal(t, begin, s.Annotations[interfaces.DowntimeBeginAnnotationKey], "declaring the same downtime again must keep its begin")
+	require.Equal(t, expiry, s.Annotations[interfaces.DowntimeExpiryAnnotationKey], "declaring the same downtime again must keep its expiry")
+	require.NotContains(t, s.Annotations, interfaces.LastOperationAnnotationKey)
+}
+
+func TestDowntime_OverlappingDowntimes_Expiry(t *testing.T) {
+	// Arrange
+	pattern := "overlapping-downtime-expiry-test-"
+
+	name := helpers.NewTestStream(t, clientSet, func(def *mockv1.TestStreamDefinition) {
+		def.Spec.RunDuration = "5s"
+		def.Spec.Suspended = false
+		def.Spec.ShouldFail = false
+		def.GenerateName = pattern
+	})
+	require.NotEmpty(t, name)
+
+	downtimeService := createDowntimeService(t)
+	expiries := map[string]time.Time{
+		"overlapping-expiry-window-1": time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+		"overlapping-expiry-window-2": time.Now().Add(3 * time.Hour).UTC().Truncate(time.Second),
+	}
+	for _, key := range []string{"overlapping-expiry-window-1", "overlapping-expiry-window-2"} {
+		err := downtimeService.DeclareDowntime(t.Context(), &models.DowntimeDeclareParameters{
+			StreamClass: "arcane-stream-mock",
+			DowntimeKey: key,
+			Prefix:      pattern,
+			Expiry:      expiries[key],
+		})
+		require.NoError(t, err)
+	}
+
+	s, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, expiries["overlapping-expiry-window-2"].Format(time.RFC3339), s.Annotations[interfaces.DowntimeExpiryAnnotationKey])
+
+	// Act
+	err = downtimeService.StopDowntime(t.Context(), &models.DowntimeStopParameters{
+		StreamClass: "arcane-stream-mock",
+		DowntimeKey: "overlapping-expiry-window-2",
+	})
+	require.NoError(t, err)
+
+	// Assert
+	s, err = clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.True(t, s.Spec.Suspended)
+	require.Equal(t, expiries["overlapping-expiry-window-1"].Format(time.RFC3339), s.Annotations[interfaces.DowntimeExpiryAnnotationKey])
+}
+
+func TestDowntime_DeclareDowntime_Concurrency(t *testing.T) {
+	// Arrange
+	const streamCount = 6
+	pattern := "declare-downtime-concurrency-test-"
+	names := make([]string, 0, streamCount)
+	for range streamCount {
+		name := helpers.NewTestStream(t, clientSet, func(def *mockv1.TestStreamDefinition) {
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = false
+			def.Spec.ShouldFail = false
+			def.GenerateName = pattern
+		})
+		require.NotEmpty(t, name)
+		names = append(names, name)
+	}
+
+	downtimeService := createDowntimeService(t)
+
+	// Act
+	err := downtimeService.DeclareDowntime(t.Context(), &models.DowntimeDeclareParameters{
+		StreamClass: "arcane-stream-mock",
+		DowntimeKey: "maintenance-window-concurrency",
+		Prefix:      pattern,
+		Queue:       models.QueueParameters{MaxAttempts: 5, Concurrency: 4},
+	})
+	require.NoError(t, err)
+
+	// Assert
+	for _, name := range names {
+		s, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "maintenance-window-concurrency", s.Labels[interfaces.DowntimeLabelKey])
+		require.True(t, s.Spec.Suspended)
+	}
+}
+
+func TestDowntime_DeclareDowntime_ChunkSize(t *testing.T) {
+	// Arrange
+	const streamCount = 5
+	pattern := "declare-downtime-chunk-size-test-"
+	names := make([]string, 0, streamCount)
+	for range streamCount {
+		name := helpers.NewTestStream(t, clientSet, func(def *mockv1.TestStreamDefinition) {
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = false
+			def.Spec.ShouldFail = false
+			def.GenerateName = pattern
+		})
+		require.NotEmpty(t, name)
+		names = append(names, name)
+	}
+
+	c, err := client.New(kubeConfig, client.Options{})
+	require.NoError(t, err)
+	clientProvider := NewFakeClientProvider(versionedv1.NewForConfigOrDie(kubeConfig), c)
+	clientProvider.chunkSize = 2
+	downtimeService := NewDowntimeService(clientProvider, NewDowntimeProcessorFactory())
+
+	// Act
+	err = downtimeService.DeclareDowntime(t.Context(), &models.DowntimeDeclareParameters{
+		DowntimeKey: "maintenance-window-chunk-size",
+		Prefix:      pattern,
+	})
+	require.NoError(t, err)
+
+	// Assert
+	for _, name := range names {
+		s, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "maintenance-window-chunk-size", s.Labels[interfaces.DowntimeLabelKey])
+		require.True(t, s.Spec.Suspended)
+	}
+}
+
 func TestDowntime_StopDowntime(t *testing.T) {
 	// Arrange
 	pattern := "stop-downtime-test-"
@@ -98,6 +434,93 @@ func TestDowntime_StopDowntime(t *testing.T) {
 	require.False(t, s.Spec.Suspended)
 }
 
+func TestDowntime_ExpireDowntimes(t *testing.T) {
+	// Arrange
+	newStreamInDowntime := func(expiry time.Time) string {
+		return helpers.NewTestStream(t, clientSet, func(def *mockv1.TestStreamDefinition) {
+			def.Labels = map[string]string{
+				interfaces.DowntimeLabelKey: "expiring-window",
+			}
+			def.Annotations = map[string]string{
+				interfaces.DowntimeBeginAnnotationKey:  time.Now().UTC().Format(time.RFC3339),
+				interfaces.DowntimeExpiryAnnotationKey: expiry.UTC().Format(time.RFC3339),
+			}
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = true
+			def.Spec.ShouldFail = false
+			def.GenerateName = "expire-downtime-test-"
+		})
+	}
+	expired := newStreamInDowntime(time.Now().Add(-time.Minute))
+	notExpired := newStreamInDowntime(time.Now().Add(time.Hour))
+
+	downtimeService := createDowntimeService(t)
+
+	// Act
+	err := downtimeService.ExpireDowntimes(t.Context(), &models.DowntimeExpireParameters{
+		StreamClass: "arcane-stream-mock",
+	})
+	require.NoError(t, err)
+
+	// Assert
+	s, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), expired, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotContains(t, s.Labels, interfaces.DowntimeLabelKey)
+	require.NotContains(t, s.Annotations, interfaces.DowntimeExpiryAnnotationKey)
+	require.False(t, s.Spec.Suspended)
+
+	s, err = clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), notExpired, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Contains(t, s.Labels, interfaces.DowntimeLabelKey)
+	require.True(t, s.Spec.Suspended)
+}
+
+func TestDowntime_ExpireDowntimes_PerKey(t *testing.T) {
+	// Arrange
+	begin := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	expired := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	live := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	downtimes, err := json.Marshal(map[string]downtimeEntry{
+		"expired-key-window": {Begin: begin, Expiry: &expired},
+		"live-key-window":    {Begin: begin, Expiry: &live},
+	})
+	require.NoError(t, err)
+
+	name := helpers.NewTestStream(t, clientSet, func(def *mockv1.TestStreamDefinition) {
+		def.Labels = map[string]string{
+			interfaces.DowntimeLabelKey: "expired-key-window",
+		}
+		def.Annotations = map[string]string{
+			interfaces.DowntimeKeysAnnotationKey:   "expired-key-window,live-key-window",
+			interfaces.DowntimesAnnotationKey:      string(downtimes),
+			interfaces.DowntimeBeginAnnotationKey:  begin.Format(time.RFC3339),
+			interfaces.DowntimeExpiryAnnotationKey: live.Format(time.RFC3339),
+		}
+		def.Spec.RunDuration = "5s"
+		def.Spec.Suspended = true
+		def.Spec.ShouldFail = false
+		def.GenerateName = "expire-downtime-per-key-test-"
+	})
+	require.NotEmpty(t, name)
+
+	downtimeService := createDowntimeService(t)
+
+	// Act
+	err = downtimeService.ExpireDowntimes(t.Context(), &models.DowntimeExpireParameters{
+		StreamClass: "arcane-stream-mock",
+	})
+	require.NoError(t, err)
+
+	// Assert
+	s, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.True(t, s.Spec.Suspended, "the stream must stay suspended while a key has not expired")
+	require.Equal(t, "live-key-window", s.Labels[interfaces.DowntimeLabelKey])
+	require.Equal(t, "live-key-window", s.Annotations[interfaces.DowntimeKeysAnnotationKey])
+	require.NotContains(t, s.Annotations[interfaces.DowntimesAnnotationKey], "expired-key-window")
+	require.Equal(t, live.Format(time.RFC3339), s.Annotations[interfaces.DowntimeExpiryAnnotationKey])
+}
+
 func TestDowntime_List_NoFilter(t *testing.T) {
 	// Arrange
 	const streamCount = 3
@@ -176,7 +599,7 @@ func createDowntimeService(t *testing.T) cmdinterfaces.DowntimeService {
 	require.NoError(t, err)
 
 	clientProvider := NewFakeClientProvider(streamingClientSet, c)
-	downtimeService := NewDowntimeService(clientProvider, NewDowntimeProcessorFactory(NewUnstructuredReader(clientProvider)))
+	downtimeService := NewDowntimeService(clientProvider, NewDowntimeProcessorFactory())
 
 	return downtimeService
 }
@@ -197,3 +620,29 @@ func WakeUp(t *testing.T, name string) error {
 
 	return waitForPhase(t, name, streamapis.Running)
 }
+
+func TestDowntimeSummarizationProcessor_Concurrent(t *testing.T) {
+	// Arrange
+	const streamCount = 50
+	processor := NewDowntimeSummarizationProcessor()
+	begin := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	// Act
+	var wg sync.WaitGroup
+	for i := range streamCount {
+		wg.Go(func() {
+			stream := &unstructured.Unstructured{}
+			stream.SetNamespace("default")
+			stream.SetName(fmt.Sprintf("summarization-test-%d", i))
+			stream.SetLabels(map[string]string{interfaces.DowntimeLabelKey: "maintenance-window-summary"})
+			stream.SetAnnotations(map[string]string{interfaces.DowntimeBeginAnnotationKey: begin})
+			_, _, err := processor.Process(t.Context(), stream, nil)
+			require.NoError(t, err)
+		})
+	}
+	wg.Wait()
+
+	// Assert
+	require.Len(t, processor.Summary["maintenance-window-summary"], streamCount)
+	require.Equal(t, begin, processor.Durations["maintenance-window-summary"].Format(time.RFC3339))
+}
@@ -2,36 +2,75 @@ package services
 
 import (
 	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
 	"os"
 	"sync"
+	"time"
 
+	v1 "github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
 	cmdinterfaces "github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/errors"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/logging"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// defaultMaxAttempts is the number of times an item is processed before it is reported as failed, if the
+// QueueOptions do not set it.
+const defaultMaxAttempts = 5
+
 type executionQueue struct {
 	clientProvider cmdinterfaces.ClientProvider
+	reader         interfaces.UnstructuredReader
 }
 
 func NewExecutionQueue(provider cmdinterfaces.ClientProvider) interfaces.ExecutionQueue {
 	return &executionQueue{
 		clientProvider: provider,
+		reader:         NewUnstructuredReader(provider),
 	}
 }
 
-func (s *executionQueue) ProcessQueue(ctx context.Context, process interfaces.UnstructuredProcessor, printer printers.ResourcePrinter, queuePublisher interfaces.QueuePublisher) error {
+// itemOutcome is the outcome of processing a single item of the queue.
+type itemOutcome int
+
+const (
+	itemModified  itemOutcome = iota // The processor modified the stream and the update was persisted.
+	itemUnchanged                    // The processor did not need to modify the stream.
+	itemSkipped                      // The processor intentionally did not modify the stream, see errors.SkippedError.
+	itemRetry                        // The attempt failed and can be retried.
+	itemFailed                       // The attempt failed and cannot be retried.
+)
+
+func (s *executionQueue) ProcessQueue(ctx context.Context, process interfaces.UnstructuredProcessor, printer printers.ResourcePrinter, queuePublisher interfaces.QueuePublisher, options interfaces.QueueOptions) error {
 	rateLimiter := workqueue.DefaultTypedControllerRateLimiter[interfaces.QueueItem]()
 	queue := workqueue.NewTypedRateLimitingQueue[interfaces.QueueItem](rateLimiter)
 	defer queue.ShutDown()
 	var wg sync.WaitGroup
 
-	wg.Go(func() {
-		s.processObjects(ctx, queue, process, printer)
+	// The workers print the modified objects, so the output of concurrent workers does not interleave
+	var printLock sync.Mutex
+	lockedPrinter := printers.ResourcePrinterFunc(func(object runtime.Object, w io.Writer) error {
+		printLock.Lock()
+		defer printLock.Unlock()
+		return printer.PrintObj(object, w)
 	})
 
+	reports := make([]*queueReport, max(options.Concurrency, 1))
+	for i := range reports {
+		wg.Go(func() {
+			reports[i] = s.processObjects(ctx, queue, rateLimiter, process, lockedPrinter, options)
+		})
+	}
+
 	err := queuePublisher.PublishStreamDefinitions(ctx, queue)
 	if err != nil { // coverage-ignore
 		return err
@@ -39,57 +78,134 @@ func (s *executionQueue) ProcessQueue(ctx context.Context, process interfaces.Un
 
 	queue.ShutDownWithDrain()
 	wg.Wait()
-	return nil
+	report := mergeQueueReports(reports)
+	if options.PrintSummary {
+		err = report.Print(os.Stderr)
+		if err != nil { // coverage-ignore
+			return err
+		}
+	}
+	return report.Err()
 }
 
-func (s *executionQueue) processObjects(ctx context.Context, queue interfaces.Queue, process interfaces.UnstructuredProcessor, printer printers.ResourcePrinter) {
+// processObjects processes the items of the queue until it is shut down and reports the outcome of each item. The
+// items are retried in place instead of being re-added to the queue, as the queue drops the items added after it
+// started to drain.
+func (s *executionQueue) processObjects(ctx context.Context, queue interfaces.Queue, rateLimiter workqueue.TypedRateLimiter[interfaces.QueueItem], process interfaces.UnstructuredProcessor, printer printers.ResourcePrinter, options interfaces.QueueOptions) *queueReport {
+	maxAttempts := options.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+
+	report := &queueReport{}
 	for {
 		select {
 		case <-ctx.Done():
-			return
+			return report
 		default:
 			item, shutdown := queue.Get()
 			if shutdown {
-				return
+				return report
 			}
 
-			updated, hasUpdated, err := process.Process(ctx, item.Definition.NamespacedName(), item.Class)
-			if err != nil {
-				logging.LogError(item.Definition.ToUnstructured(), "modifying object, will retry later", err)
-				queue.AddRateLimited(item)
-				continue
-			}
+			outcome, attempts, err := s.processItem(ctx, item, rateLimiter, maxAttempts, process, printer, options)
+			queue.Forget(item)
+			queue.Done(item)
 
-			if !hasUpdated { // coverage-ignore
-				// If the processor indicates that there's no update needed
-				queue.Forget(item)
-				queue.Done(item)
-				continue
+			name := item.Definition.NamespacedName()
+			switch outcome {
+			case itemModified:
+				report.Modified++
+			case itemUnchanged:
+				report.Unchanged++
+			case itemSkipped:
+				report.Skipped = append(report.Skipped, skippedItem{Name: name, Reason: err.Error()})
+			default:
+				report.Failed = append(report.Failed, errors.ItemFailure{Name: name, Attempts: attempts, Err: err})
 			}
+		}
+	}
+}
 
-			unstructuredClient, err := s.clientProvider.ProvideUnstructuredClient()
-			if err != nil {
-				logging.LogError(item.Definition.ToUnstructured(), "in constructing kubernetes client, will not retry", err)
-				// If we can't get a client, there's no point in retrying, so we forget the item and move on.
-				queue.Forget(item)
-				queue.Done(item)
-				continue
-			}
+// processItem processes the item until it succeeds or was attempted maxAttempts times, waiting for the backoff of the
+// rate limiter between the attempts. The first attempt processes the listed object, the stream is only fetched again
+// if it was modified concurrently. It returns the outcome, the number of attempts and the error of the last one.
+func (s *executionQueue) processItem(ctx context.Context, item interfaces.QueueItem, rateLimiter workqueue.TypedRateLimiter[interfaces.QueueItem], maxAttempts int, process interfaces.UnstructuredProcessor, printer printers.ResourcePrinter, options interfaces.QueueOptions) (itemOutcome, int, error) {
+	defer rateLimiter.Forget(item)
 
-			err = unstructuredClient.Update(ctx, updated)
-			if err != nil {
-				logging.LogError(item.Definition.ToUnstructured(), "updating client, will retry later", err)
-				queue.AddRateLimited(item)
-				continue
-			}
+	stream := item.Definition.ToUnstructured()
+	for attempt := 1; ; attempt++ {
+		outcome, err := s.processOnce(ctx, stream, item.Class, process, printer, options)
+		switch {
+		case outcome == itemSkipped:
+			logging.LogProgress(stream, "skipped: "+err.Error())
+			return outcome, attempt, err
+		case outcome != itemRetry && outcome != itemFailed:
+			return outcome, attempt, nil
+		case outcome == itemFailed || attempt >= maxAttempts || ctx.Err() != nil:
+			logging.LogError(stream, fmt.Sprintf("processing object after %d attempts, giving up", attempt), err)
+			return itemFailed, attempt, err
+		}
 
-			queue.Forget(item)
-			queue.Done(item)
-			err = printer.PrintObj(updated, os.Stdout)
-			if err != nil {
-				// If we can't print, we still consider the item processed successfully, so we forget it and move on.
-				continue
+		if apierrors.IsConflict(err) {
+			// The stream was modified since it was read, so the next attempt processes its current state
+			current, readErr := s.reader.Read(ctx, item.Class, item.Definition.NamespacedName())
+			if readErr != nil {
+				logging.LogError(stream, "fetching object after a conflict, will retry later", readErr)
+			} else {
+				stream = current
 			}
 		}
+
+		logging.LogError(stream, "processing object, will retry later", err)
+		select {
+		case <-time.After(rateLimiter.When(item)):
+		case <-ctx.Done():
+			return itemFailed, attempt, err
+		}
 	}
 }
+
+// processOnce processes a copy of the stream, persists the changes as a JSON merge patch that fails with a conflict if
+// the stream was modified since it was read, prints the update and returns the outcome of the attempt.
+func (s *executionQueue) processOnce(ctx context.Context, stream *unstructured.Unstructured, class *v1.StreamClass, process interfaces.UnstructuredProcessor, printer printers.ResourcePrinter, options interfaces.QueueOptions) (itemOutcome, error) {
+	updated, hasUpdated, err := process.Process(ctx, stream.DeepCopy(), class)
+	var skippedErr *errors.SkippedError
+	if stderrors.As(err, &skippedErr) {
+		return itemSkipped, err
+	}
+	if err != nil {
+		return itemRetry, fmt.Errorf("modifying object: %w", err)
+	}
+
+	if !hasUpdated { // coverage-ignore
+		// If the processor indicates that there's no update needed
+		return itemUnchanged, nil
+	}
+
+	if options.DryRun == models.DryRunClient {
+		// In client dry run mode, the modified object is only printed and never sent to the server.
+		_ = printer.PrintObj(updated, os.Stdout)
+		return itemModified, nil
+	}
+
+	unstructuredClient, err := s.clientProvider.ProvideUnstructuredClient()
+	if err != nil {
+		// If we can't get a client, there's no point in retrying.
+		return itemFailed, fmt.Errorf("constructing kubernetes client: %w", err)
+	}
+
+	patchOptions := []client.PatchOption{client.FieldOwner(fieldManager)}
+	if options.DryRun == models.DryRunServer {
+		patchOptions = append(patchOptions, client.DryRunAll)
+	}
+	patch := client.MergeFromWithOptions(stream, client.MergeFromWithOptimisticLock{})
+	err = unstructuredClient.Patch(ctx, updated, patch, patchOptions...)
+	if err != nil {
+		return itemRetry, fmt.Errorf("patching object: %w", err)
+	}
+
+	// If we can't print, we still consider the item processed successfully.
+	_ = printer.PrintObj(updated, os.Stdout)
+	return itemModified, nil
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	v1 "github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
+	versionedv1 "github.com/SneaksAndData/arcane-operator/pkg/generated/clientset/versioned"
+	mockv1 "github.com/SneaksAndData/arcane-stream-mock/pkg/apis/streaming/v1"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/errors"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/logging"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/filter"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/publisher"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/tests/helpers"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ interfaces.UnstructuredProcessor = (*failingProcessor)(nil)
+var _ interfaces.UnstructuredProcessor = (*skippingProcessor)(nil)
+var _ interfaces.UnstructuredProcessor = (*conflictingProcessor)(nil)
+
+// failingProcessor fails every attempt, like a stream whose update is always rejected by an admission webhook.
+type failingProcessor struct {
+	attempts atomic.Int32
+}
+
+func (p *failingProcessor) Process(_ context.Context, _ *unstructured.Unstructured, _ *v1.StreamClass) (*unstructured.Unstructured, bool, error) {
+	p.attempts.Add(1)
+	return nil, false, fmt.Errorf("admission webhook denied the request")
+}
+
+// skippingProcessor skips every stream, like a stream that belongs to a different downtime.
+type skippingProcessor struct{}
+
+func (p *skippingProcessor) Process(_ context.Context, _ *unstructured.Unstructured, _ *v1.StreamClass) (*unstructured.Unstructured, bool, error) {
+	return nil, false, errors.NewSkippedError("has a different downtime key")
+}
+
+// conflictingProcessor modifies the stream on the server during its first attempt, like a concurrent update of the
+// operator, so the patch of the first attempt conflicts.
+type conflictingProcessor struct {
+	client   client.Client
+	attempts atomic.Int32
+}
+
+func (p *conflictingProcessor) Process(ctx context.Context, stream *unstructured.Unstructured, _ *v1.StreamClass) (*unstructured.Unstructured, bool, error) {
+	if p.attempts.Add(1) == 1 {
+		concurrent := stream.DeepCopy()
+		concurrent.SetAnnotations(map[string]string{"concurrent": "true"})
+		err := p.client.Update(ctx, concurrent)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	annotations := stream.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations["processed"] = "true"
+	stream.SetAnnotations(annotations)
+	return stream, true, nil
+}
+
+func TestExecutionQueue_MaxAttempts(t *testing.T) {
+	// Arrange
+	pattern := "execution-queue-max-attempts-test-"
+	name := helpers.NewTestStream(t, clientSet, func(def *mockv1.TestStreamDefinition) {
+		def.Spec.RunDuration = "5s"
+		def.Spec.Suspended = true
+		def.Spec.ShouldFail = false
+		def.GenerateName = pattern
+	})
+	require.NotEmpty(t, name)
+
+	c, err := client.New(kubeConfig, client.Options{})
+	require.NoError(t, err)
+	clientProvider := NewFakeClientProvider(versionedv1.NewForConfigOrDie(kubeConfig), c)
+	queuePublisher := publisher.NewStreamClassMembersPublisher(clientProvider, "arcane-stream-mock", "default", filter.NewByNamePrefix(pattern), &client.MatchingLabelsSelector{})
+	processor := &failingProcessor{}
+
+	// Act
+	err = NewExecutionQueue(clientProvider).ProcessQueue(t.Context(), processor, logging.Printer(""), queuePublisher, interfaces.QueueOptions{MaxAttempts: 3})
+
+	// Assert
+	var processingErr *errors.ProcessingError
+	require.True(t, stderrors.As(err, &processingErr))
+	require.Len(t, processingErr.Failures, 1)
+	require.Equal(t, types.NamespacedName{Namespace: "default", Name: name}, processingErr.Failures[0].Name)
+	require.Equal(t, 3, processingErr.Failures[0].Attempts)
+	require.EqualValues(t, 3, processor.attempts.Load())
+	require.Equal(t, errors.ExitCodePartialFailure, errors.ExitCode(err))
+}
+
+func TestExecutionQueue_Skipped(t *testing.T) {
+	// Arrange
+	pattern := "execution-queue-skipped-test-"
+	name := helpers.NewTestStream(t, clientSet, func(def *mockv1.TestStreamDefinition) {
+		def.Spec.RunDuration = "5s"
+		def.Spec.Suspended = true
+		def.Spec.ShouldFail = false
+		def.GenerateName = pattern
+	})
+	require.NotEmpty(t, name)
+
+	c, err := client.New(kubeConfig, client.Options{})
+	require.NoError(t, err)
+	clientProvider := NewFakeClientProvider(versionedv1.NewForConfigOrDie(kubeConfig), c)
+	queuePublisher := publisher.NewStreamClassMembersPublisher(clientProvider, "arcane-stream-mock", "default", filter.NewByNamePrefix(pattern), &client.MatchingLabelsSelector{})
+
+	// Act
+	err = NewExecutionQueue(clientProvider).ProcessQueue(t.Context(), &skippingProcessor{}, logging.Printer(""), queuePublisher, interfaces.QueueOptions{PrintSummary: true})
+
+	// Assert
+	require.Error(t, err)
+	require.Equal(t, errors.ExitCodePartialFailure, errors.ExitCode(err))
+	var processingErr *errors.ProcessingError
+	require.False(t, stderrors.As(err, &processingErr))
+}
+
+func TestQueueReport_Print(t *testing.T) {
+	report := &queueReport{
+		Modified:  3,
+		Unchanged: 1,
+		Skipped:   []skippedItem{{Name: types.NamespacedName{Namespace: "default", Name: "stream-a"}, Reason: "has a different downtime key"}},
+		Failed:    []errors.ItemFailure{{Name: types.NamespacedName{Namespace: "default", Name: "stream-b"}, Attempts: 5, Err: fmt.Errorf("denied")}},
+	}
+
+	var output strings.Builder
+	err := report.Print(&output)
+
+	require.NoError(t, err)
+	require.Equal(t, `Summary: 6 streams, 3 modified, 1 unchanged, 1 skipped, 1 failed
+  default/stream-a skipped: has a different downtime key
+  default/stream-b failed after 5 attempts: denied
+`, output.String())
+	require.Equal(t, errors.ExitCodePartialFailure, errors.ExitCode(report.Err()))
+	require.NoError(t, (&queueReport{Modified: 1}).Err())
+}
+
+func TestExecutionQueue_Conflict(t *testing.T) {
+	// Arrange
+	pattern := "execution-queue-conflict-test-"
+	name := helpers.NewTestStream(t, clientSet, func(def *mockv1.TestStreamDefinition) {
+		def.Spec.RunDuration = "5s"
+		def.Spec.Suspended = true
+		def.Spec.ShouldFail = false
+		def.GenerateName = pattern
+	})
+	require.NotEmpty(t, name)
+
+	c, err := client.New(kubeConfig, client.Options{})
+	require.NoError(t, err)
+	clientProvider := NewFakeClientProvider(versionedv1.NewForConfigOrDie(kubeConfig), c)
+	queuePublisher := publisher.NewStreamClassMembersPublisher(clientProvider, "arcane-stream-mock", "default", filter.NewByNamePrefix(pattern), &client.MatchingLabelsSelector{})
+	processor := &conflictingProcessor{client: c}
+
+	// Act
+	err = NewExecutionQueue(clientProvider).ProcessQueue(t.Context(), processor, logging.Printer(""), queuePublisher, interfaces.QueueOptions{})
+
+	// Assert
+	require.NoError(t, err)
+	require.EqualValues(t, 2, processor.attempts.Load())
+	stream, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "true", stream.Annotations["concurrent"])
+	require.Equal(t, "true", stream.Annotations["processed"])
+}
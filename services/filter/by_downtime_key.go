@@ -1,8 +1,12 @@
 package filter
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/SneaksAndData/arcane-operator/services/controllers/stream"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 var _ interfaces.ObjectFilter = (*ByDowntimeKey)(nil)
@@ -18,5 +22,32 @@ func NewByDowntimeKey(key string) *ByDowntimeKey {
 }
 
 func (f *ByDowntimeKey) Matches(definition stream.Definition) (bool, error) {
-	return definition.ToUnstructured().GetLabels()[interfaces.DowntimeLabelKey] == f.key, nil
+	return slices.Contains(DowntimeKeys(definition.ToUnstructured()), f.key), nil
+}
+
+var _ interfaces.ObjectFilter = (*InDowntime)(nil)
+
+// InDowntime matches the stream definitions that are in downtime for any key.
+type InDowntime struct{}
+
+func NewInDowntime() *InDowntime {
+	return &InDowntime{}
+}
+
+func (f *InDowntime) Matches(definition stream.Definition) (bool, error) {
+	return len(DowntimeKeys(definition.ToUnstructured())) > 0, nil
+}
+
+// DowntimeKeys returns all downtime keys of the object. Objects declared in downtime before the downtimes of several
+// keys could overlap only carry the DowntimeLabelKey label.
+func DowntimeKeys(object *unstructured.Unstructured) []string {
+	key, ok := object.GetLabels()[interfaces.DowntimeLabelKey]
+	if !ok {
+		return nil
+	}
+	value, ok := object.GetAnnotations()[interfaces.DowntimeKeysAnnotationKey]
+	if !ok || value == "" {
+		return []string{key}
+	}
+	return strings.Split(value, ",")
 }
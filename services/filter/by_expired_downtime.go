@@ -0,0 +1,65 @@
+package filter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/SneaksAndData/arcane-operator/services/controllers/stream"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ interfaces.ObjectFilter = (*ByExpiredDowntime)(nil)
+
+// ByExpiredDowntime matches streams in downtime with at least one downtime key whose expiry has passed.
+type ByExpiredDowntime struct {
+	now time.Time
+}
+
+func NewByExpiredDowntime(now time.Time) *ByExpiredDowntime {
+	return &ByExpiredDowntime{
+		now: now,
+	}
+}
+
+func (f *ByExpiredDowntime) Matches(definition stream.Definition) (bool, error) {
+	object := definition.ToUnstructured()
+	if _, inDowntime := object.GetLabels()[interfaces.DowntimeLabelKey]; !inDowntime {
+		return false, nil
+	}
+
+	for _, expiry := range downtimeExpiries(object) {
+		if !f.now.Before(expiry) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// downtimeExpiries returns the expiry of each downtime key of the object that expires. Keys without their own downtime
+// entry share the expiry of the object, and streams with an invalid expiry never expire.
+func downtimeExpiries(object *unstructured.Unstructured) []time.Time {
+	var entries map[string]struct {
+		Expiry *time.Time `json:"expiry,omitempty"`
+	}
+	if value, ok := object.GetAnnotations()[interfaces.DowntimesAnnotationKey]; ok {
+		if json.Unmarshal([]byte(value), &entries) != nil {
+			entries = nil // Invalid entries fall back to the expiry of the object
+		}
+	}
+
+	shared, err := time.Parse(time.RFC3339, object.GetAnnotations()[interfaces.DowntimeExpiryAnnotationKey])
+	hasShared := err == nil
+
+	var expiries []time.Time
+	for _, key := range DowntimeKeys(object) {
+		entry, ok := entries[key]
+		switch {
+		case ok && entry.Expiry != nil:
+			expiries = append(expiries, *entry.Expiry)
+		case !ok && hasShared:
+			expiries = append(expiries, shared)
+		}
+	}
+	return expiries
+}
@@ -7,18 +7,18 @@ import (
 	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
 )
 
-var _ interfaces.ObjectFilter = (*UnsuspendedByNamePrefix)(nil)
+var _ interfaces.ObjectFilter = (*ByNamePrefix)(nil)
 
-type UnsuspendedByNamePrefix struct {
+type ByNamePrefix struct {
 	Prefix string
 }
 
-func NewUnsuspendedByNamePrefix(prefix string) *UnsuspendedByNamePrefix {
-	return &UnsuspendedByNamePrefix{
+func NewByNamePrefix(prefix string) *ByNamePrefix {
+	return &ByNamePrefix{
 		Prefix: prefix,
 	}
 }
 
-func (f *UnsuspendedByNamePrefix) Matches(definition stream.Definition) (bool, error) {
-	return strings.HasPrefix(definition.ToUnstructured().GetName(), f.Prefix) && !definition.Suspended(), nil
+func (f *ByNamePrefix) Matches(definition stream.Definition) (bool, error) {
+	return strings.HasPrefix(definition.ToUnstructured().GetName(), f.Prefix), nil
 }
@@ -0,0 +1,24 @@
+package filter
+
+import (
+	"regexp"
+
+	"github.com/SneaksAndData/arcane-operator/services/controllers/stream"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+)
+
+var _ interfaces.ObjectFilter = (*ByNameRegex)(nil)
+
+type ByNameRegex struct {
+	pattern *regexp.Regexp
+}
+
+func NewByNameRegex(pattern *regexp.Regexp) *ByNameRegex {
+	return &ByNameRegex{
+		pattern: pattern,
+	}
+}
+
+func (f *ByNameRegex) Matches(definition stream.Definition) (bool, error) {
+	return f.pattern.MatchString(definition.ToUnstructured().GetName()), nil
+}
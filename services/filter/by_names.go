@@ -0,0 +1,27 @@
+package filter
+
+import (
+	"github.com/SneaksAndData/arcane-operator/services/controllers/stream"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+)
+
+var _ interfaces.ObjectFilter = (*ByNames)(nil)
+
+type ByNames struct {
+	names map[string]struct{}
+}
+
+func NewByNames(names []string) *ByNames {
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		set[name] = struct{}{}
+	}
+	return &ByNames{
+		names: set,
+	}
+}
+
+func (f *ByNames) Matches(definition stream.Definition) (bool, error) {
+	_, ok := f.names[definition.ToUnstructured().GetName()]
+	return ok, nil
+}
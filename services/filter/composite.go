@@ -0,0 +1,83 @@
+package filter
+
+import (
+	"github.com/SneaksAndData/arcane-operator/services/controllers/stream"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+)
+
+var _ interfaces.ObjectFilter = (*AllOf)(nil)
+
+// AllOf matches the stream definitions that match all of its filters.
+type AllOf struct {
+	filters []interfaces.ObjectFilter
+}
+
+func NewAllOf(filters ...interfaces.ObjectFilter) *AllOf {
+	return &AllOf{
+		filters: filters,
+	}
+}
+
+func (f *AllOf) Matches(definition stream.Definition) (bool, error) {
+	for _, objectFilter := range f.filters {
+		matches, err := objectFilter.Matches(definition)
+		if err != nil || !matches {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+var _ interfaces.ObjectFilter = (*AnyOf)(nil)
+
+// AnyOf matches the stream definitions that match any of its filters.
+type AnyOf struct {
+	filters []interfaces.ObjectFilter
+}
+
+func NewAnyOf(filters ...interfaces.ObjectFilter) *AnyOf {
+	return &AnyOf{
+		filters: filters,
+	}
+}
+
+func (f *AnyOf) Matches(definition stream.Definition) (bool, error) {
+	for _, objectFilter := range f.filters {
+		matches, err := objectFilter.Matches(definition)
+		if err != nil || matches {
+			return matches, err
+		}
+	}
+	return false, nil
+}
+
+var _ interfaces.ObjectFilter = (*Not)(nil)
+
+// Not matches the stream definitions that do not match its filter.
+type Not struct {
+	filter interfaces.ObjectFilter
+}
+
+func NewNot(filter interfaces.ObjectFilter) *Not {
+	return &Not{
+		filter: filter,
+	}
+}
+
+func (f *Not) Matches(definition stream.Definition) (bool, error) {
+	matches, err := f.filter.Matches(definition)
+	return !matches, err
+}
+
+var _ interfaces.ObjectFilter = (*Unsuspended)(nil)
+
+// Unsuspended matches the stream definitions that are not suspended.
+type Unsuspended struct{}
+
+func NewUnsuspended() *Unsuspended {
+	return &Unsuspended{}
+}
+
+func (f *Unsuspended) Matches(definition stream.Definition) (bool, error) {
+	return !definition.Suspended(), nil
+}
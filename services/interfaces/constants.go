@@ -3,5 +3,35 @@ package interfaces
 // DowntimeLabelKey is the label key used to identify resources that are in downtime.
 const DowntimeLabelKey = "arcane.sneaksanddata.com/downtime"
 
-// DowntimeBeginAnnotationKey is the label key used to store the timestamp of when the downtime was declared, in milliseconds since epoch.
+// DowntimeKeysAnnotationKey is the annotation key used to store the comma-separated list of all downtime keys of a resource,
+// when the downtimes of several keys overlap. The DowntimeLabelKey label holds the first of them.
+const DowntimeKeysAnnotationKey = "arcane.sneaksanddata.com/downtime-keys"
+
+// DowntimesAnnotationKey is the annotation key used to store the begin and the expiry of the downtime of a resource per
+// downtime key, as a JSON object. The DowntimeBeginAnnotationKey and DowntimeExpiryAnnotationKey annotations hold the
+// earliest begin and the combined expiry of all keys.
+const DowntimesAnnotationKey = "arcane.sneaksanddata.com/downtimes"
+
+// DowntimeBeginAnnotationKey is the annotation key used to store the timestamp of when the downtime was declared, in RFC3339 format.
 const DowntimeBeginAnnotationKey = "arcane.sneaksanddata.com/downtime-begin-ts"
+
+// DowntimeExpiryAnnotationKey is the annotation key used to store the timestamp after which the downtime can be ended by `downtime expire`, in RFC3339 format.
+const DowntimeExpiryAnnotationKey = "arcane.sneaksanddata.com/downtime-expiry-ts"
+
+// StreamIdJobLabelKey is the label key set by the operator on the jobs it creates for a stream, holding the stream name.
+const StreamIdJobLabelKey = "arcane/stream-id"
+
+// LastOperationAnnotationKey is the annotation key used to store the name of the last operation performed on a resource by the plugin, e.g. stream-stop.
+const LastOperationAnnotationKey = "arcane.sneaksanddata.com/last-operation"
+
+// LastModifiedByAnnotationKey is the annotation key used to store the user that performed the last operation on a resource.
+const LastModifiedByAnnotationKey = "arcane.sneaksanddata.com/last-modified-by"
+
+// LastModifiedAtAnnotationKey is the annotation key used to store the timestamp of the last operation on a resource, in RFC3339 format.
+const LastModifiedAtAnnotationKey = "arcane.sneaksanddata.com/last-modified-at"
+
+// ReasonAnnotationKey is the annotation key used to store the reason given for the last operation on a resource.
+const ReasonAnnotationKey = "arcane.sneaksanddata.com/reason"
+
+// TicketAnnotationKey is the annotation key used to store the ticket reference given for the last operation on a resource.
+const TicketAnnotationKey = "arcane.sneaksanddata.com/ticket"
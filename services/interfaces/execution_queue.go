@@ -11,5 +11,7 @@ import (
 type ExecutionQueue interface {
 
 	// ProcessQueue processes items from the queue using the provided UnstructuredProcessor, printing results with the given ResourcePrinter,
-	ProcessQueue(ctx context.Context, process UnstructuredProcessor, printer printers.ResourcePrinter, queuePublisher QueuePublisher) error
+	// and returns an error with the errors.ExitCodePartialFailure exit code if any item was skipped or could not be processed
+	// within the QueueOptions.MaxAttempts.
+	ProcessQueue(ctx context.Context, process UnstructuredProcessor, printer printers.ResourcePrinter, queuePublisher QueuePublisher, options QueueOptions) error
 }
@@ -5,6 +5,8 @@ import (
 	"github.com/SneaksAndData/arcane-operator/services/controllers/stream"
 )
 
+// QueueItem is a stream definition to be processed by the execution queue. The definition wraps the listed object,
+// including its resourceVersion, so the queue can patch it without fetching it again.
 type QueueItem struct {
 	Definition stream.Definition
 	Class      *v1.StreamClass
@@ -0,0 +1,22 @@
+package interfaces
+
+import "github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+
+// QueueOptions defines the settings of a single ExecutionQueue run.
+type QueueOptions struct {
+	// DryRun defines whether the objects modified by the processor are persisted, see models.DryRunStrategy.
+	DryRun models.DryRunStrategy
+
+	// MaxAttempts is the number of times an item is processed before it is reported as failed. Zero means the default
+	// of the ExecutionQueue.
+	MaxAttempts int
+
+	// Concurrency is the number of items processed at the same time. Zero means a single worker. Every
+	// UnstructuredProcessor must be safe for concurrent use, so the concurrency of a command can be raised without
+	// changing its processor.
+	Concurrency int
+
+	// PrintSummary defines whether the number of modified, unchanged, skipped and failed items is printed on stderr
+	// once all items are processed.
+	PrintSummary bool
+}
@@ -26,6 +26,7 @@ var _ interfaces.ClientProvider = (*FakeClientProvider)(nil)
 type FakeClientProvider struct {
 	clientSet          *versionedv1.Clientset
 	unstructuredClient client.Client
+	chunkSize          int64
 }
 
 func (f FakeClientProvider) ProvideClientSet() (*versionedv1.Clientset, error) {
@@ -36,6 +37,10 @@ func (f FakeClientProvider) ProvideUnstructuredClient() (client.Client, error) {
 	return f.unstructuredClient, nil
 }
 
+func (f FakeClientProvider) ChunkSize() int64 {
+	return f.chunkSize
+}
+
 func NewFakeClientProvider(clientSet *versionedv1.Clientset, unstructuredClient client.Client) *FakeClientProvider {
 	return &FakeClientProvider{
 		clientSet:          clientSet,
@@ -4,7 +4,6 @@ import (
 	"context"
 
 	cmdinterfaces "github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
-	"github.com/sneaksAndData/kubectl-plugin-arcane/services/filter"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -13,14 +12,18 @@ import (
 var _ interfaces.QueuePublisher = (*AllStreamDefinitions)(nil)
 
 type AllStreamDefinitions struct {
-	provider cmdinterfaces.ClientProvider
-	selector *pkgclient.MatchingLabelsSelector
+	provider     cmdinterfaces.ClientProvider
+	namespace    string
+	objectFilter interfaces.ObjectFilter
+	selector     *pkgclient.MatchingLabelsSelector
 }
 
-func NewAllStreamDefinitionsPublisher(provider cmdinterfaces.ClientProvider, selector *pkgclient.MatchingLabelsSelector) *AllStreamDefinitions {
+func NewAllStreamDefinitionsPublisher(provider cmdinterfaces.ClientProvider, namespace string, objectFilter interfaces.ObjectFilter, selector *pkgclient.MatchingLabelsSelector) *AllStreamDefinitions {
 	return &AllStreamDefinitions{
-		provider: provider,
-		selector: selector,
+		provider:     provider,
+		namespace:    namespace,
+		objectFilter: objectFilter,
+		selector:     selector,
 	}
 }
 
@@ -30,18 +33,24 @@ func (a AllStreamDefinitions) PublishStreamDefinitions(ctx context.Context, targ
 		return err
 	}
 
-	streamClasses, err := client.StreamingV1().StreamClasses("").List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return err
-	}
-
-	for _, sc := range streamClasses.Items {
-		queuePublisher := NewStreamClassMembersPublisher(a.provider, sc.Name, "", filter.NewAllowAll(), a.selector)
-		err = queuePublisher.PublishStreamDefinitions(ctx, target)
+	options := metav1.ListOptions{Limit: a.provider.ChunkSize()}
+	for {
+		streamClasses, err := client.StreamingV1().StreamClasses("").List(ctx, options)
 		if err != nil {
 			return err
 		}
-	}
 
-	return nil
+		for _, sc := range streamClasses.Items {
+			queuePublisher := NewStreamClassMembersPublisher(a.provider, sc.Name, a.namespace, a.objectFilter, a.selector)
+			err = queuePublisher.PublishStreamDefinitions(ctx, target)
+			if err != nil {
+				return err
+			}
+		}
+
+		options.Continue = streamClasses.Continue
+		if options.Continue == "" {
+			return nil
+		}
+	}
 }
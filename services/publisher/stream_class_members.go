@@ -3,6 +3,7 @@ package publisher
 import (
 	"context"
 
+	v1 "github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
 	"github.com/SneaksAndData/arcane-operator/services/controllers/contracts"
 	cmdinterfaces "github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/logging"
@@ -60,12 +61,23 @@ func (s StreamClassMembers) PublishStreamDefinitions(ctx context.Context, queue
 		return err
 	}
 
-	err = unstructuredClient.List(ctx, streamList, client.InNamespace(s.namespace), s.selector)
-	if err != nil { // coverage-ignore
-		return err
+	// The streams are listed in chunks, so the queue starts processing the first streams while the next ones are listed
+	for {
+		err = unstructuredClient.List(ctx, streamList, client.InNamespace(s.namespace), s.selector, client.Limit(s.clientProvider.ChunkSize()), client.Continue(streamList.GetContinue()))
+		if err != nil { // coverage-ignore
+			return err
+		}
+
+		s.publishItems(streamList.Items, sc, queue)
+		if streamList.GetContinue() == "" {
+			return nil
+		}
 	}
+}
 
-	for _, item := range streamList.Items {
+// publishItems adds the items of a single chunk that are stream definitions matching the object filter to the queue.
+func (s StreamClassMembers) publishItems(items []unstructured.Unstructured, sc *v1.StreamClass, queue interfaces.Queue) {
+	for _, item := range items {
 		streamDefinition, err := contracts.FromUnstructured(&item)
 		if err != nil {
 			logging.LogError(&item, "parsing kubernetes object, skipping", err)
@@ -82,6 +94,4 @@ func (s StreamClassMembers) PublishStreamDefinitions(ctx context.Context, queue
 		}
 		queue.Add(interfaces.QueueItem{Definition: streamDefinition, Class: sc})
 	}
-
-	return nil
 }
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/sneaksAndData/kubectl-plugin-arcane/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// skippedItem is a stream that a processor intentionally did not modify, together with the reason.
+type skippedItem struct {
+	Name   types.NamespacedName
+	Reason string
+}
+
+// queueReport is the result of a single ExecutionQueue run.
+type queueReport struct {
+	Modified  int
+	Unchanged int
+	Skipped   []skippedItem
+	Failed    []errors.ItemFailure
+}
+
+// mergeQueueReports merges the reports of the workers of a single ExecutionQueue run, ordering the skipped and failed
+// streams by name.
+func mergeQueueReports(reports []*queueReport) *queueReport {
+	merged := &queueReport{}
+	for _, report := range reports {
+		merged.Modified += report.Modified
+		merged.Unchanged += report.Unchanged
+		merged.Skipped = append(merged.Skipped, report.Skipped...)
+		merged.Failed = append(merged.Failed, report.Failed...)
+	}
+	sort.SliceStable(merged.Skipped, func(i, j int) bool {
+		return merged.Skipped[i].Name.String() < merged.Skipped[j].Name.String()
+	})
+	sort.SliceStable(merged.Failed, func(i, j int) bool {
+		return merged.Failed[i].Name.String() < merged.Failed[j].Name.String()
+	})
+	return merged
+}
+
+// Err returns an error with the ExitCodePartialFailure exit code if any stream was skipped or failed.
+func (r *queueReport) Err() error {
+	switch {
+	case len(r.Failed) > 0:
+		return errors.NewPartialFailureError(errors.NewProcessingError(r.Failed))
+	case len(r.Skipped) > 0:
+		return errors.NewPartialFailureError(fmt.Errorf("skipped %d streams", len(r.Skipped)))
+	default:
+		return nil
+	}
+}
+
+// Print prints the number of streams per result, followed by the streams that were skipped or failed.
+func (r *queueReport) Print(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "Summary: %d streams, %d modified, %d unchanged, %d skipped, %d failed\n",
+		r.Modified+r.Unchanged+len(r.Skipped)+len(r.Failed),
+		r.Modified,
+		r.Unchanged,
+		len(r.Skipped),
+		len(r.Failed))
+	if err != nil {
+		return err
+	}
+
+	for _, item := range r.Skipped {
+		_, err = fmt.Fprintf(w, "  %s skipped: %s\n", item.Name, item.Reason)
+		if err != nil {
+			return err
+		}
+	}
+	for _, item := range r.Failed {
+		_, err = fmt.Fprintf(w, "  %s failed after %d attempts: %v\n", item.Name, item.Attempts, item.Err)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,85 +3,333 @@ package services
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"time"
 
+	v1 "github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
 	"github.com/SneaksAndData/arcane-operator/services/controllers/contracts"
 	streamapis "github.com/SneaksAndData/arcane-operator/services/controllers/stream"
-	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
+	cmdinterfaces "github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/errors"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/logging"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/filter"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/publisher"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const fieldManager = "kubectl-arcane"
 
-// Ensure stream implements interfaces.StreamService
-var _ interfaces.StreamService = (*stream)(nil)
+// conflictRetryBackoff limits the number of attempts to update a stream definition that is modified concurrently.
+var conflictRetryBackoff = retry.DefaultRetry
+
+// Ensure stream implements cmdinterfaces.StreamService
+var _ cmdinterfaces.StreamService = (*stream)(nil)
 
 // stream is a service that provides stream operations.
 type stream struct {
-	clientProvider interfaces.ClientProvider
+	clientProvider cmdinterfaces.ClientProvider
+	reader         interfaces.UnstructuredReader
+	executionQueue interfaces.ExecutionQueue
+	auditor        *auditor
 }
 
 // NewStreamService creates a new instance of the stream, which provides stream operations.
-func NewStreamService(clientProvider interfaces.ClientProvider) interfaces.StreamService {
+func NewStreamService(clientProvider cmdinterfaces.ClientProvider, reader interfaces.UnstructuredReader) cmdinterfaces.StreamService {
 	return &stream{
 		clientProvider: clientProvider,
+		reader:         reader,
+		executionQueue: NewExecutionQueue(clientProvider),
+		auditor:        newAuditor(clientProvider),
 	}
 }
 
 // Start is a method that allows users to start a stream, use the <key> parameter to identify the stream to start
 func (s *stream) Start(ctx context.Context, parameters *models.StartParameters) error {
-	printer := logging.Printer("started")
-	return wait.PollUntilContextCancel(ctx, 1*time.Second, true, func(ctx context.Context) (done bool, err error) {
-		err = s.modifyStreamDefinition(ctx,
-			parameters.Namespace,
-			parameters.StreamClass,
-			parameters.StreamId,
-			streamapis.Running,
-			func(def streamapis.Definition) error {
-				return def.SetSuspended(false)
-			},
-			func(definition streamapis.Definition) bool {
-				return definition.Suspended()
-			},
-			printer,
-		)
-		if err == nil || apierrors.IsConflict(err) {
-			return true, nil
-		}
-		return false, err
-	})
+	printer, err := s.modificationPrinter(parameters.Output, parameters.DryRun.Operation("started"), parameters.Wait)
+	if err != nil {
+		return err
+	}
+	audit := s.auditor.NewRecord(ctx, operationStreamStart, parameters.Audit, parameters.DryRun)
+	if parameters.Selection != nil {
+		return s.modifyStreamSelection(ctx, parameters.StreamClass, parameters.Namespace, parameters.Selection, false, audit, parameters.DryRun, parameters.Queue, printer)
+	}
+	err = s.modifyStreamDefinition(ctx,
+		parameters.Namespace,
+		parameters.StreamClass,
+		parameters.StreamId,
+		streamapis.Running,
+		func(def streamapis.Definition) error {
+			audit.Apply(def.ToUnstructured())
+			return def.SetSuspended(false)
+		},
+		func(definition streamapis.Definition) bool {
+			return definition.Suspended()
+		},
+		parameters.DryRun,
+		printer,
+	)
+	if err != nil || !parameters.Wait {
+		return err
+	}
+
+	namespacedName := types.NamespacedName{Namespace: parameters.Namespace, Name: parameters.StreamId}
+	return s.waitForPhase(ctx, parameters.StreamClass, namespacedName, streamapis.Running, false, parameters.Timeout, parameters.Output)
 }
 
 // Stop is a method that allows users to stop a stream, use the <key> parameter to identify the stream to stop
 func (s *stream) Stop(ctx context.Context, parameters *models.StopParameters) error {
-	printer := logging.Printer("stopped")
-	return wait.PollUntilContextCancel(ctx, 1*time.Second, true, func(ctx context.Context) (done bool, err error) {
-		err = s.modifyStreamDefinition(ctx,
-			parameters.Namespace,
-			parameters.StreamClass,
-			parameters.StreamId,
-			streamapis.Suspended,
-			func(def streamapis.Definition) error {
-				return def.SetSuspended(true)
-			},
-			func(definition streamapis.Definition) bool {
-				return !definition.Suspended()
-			},
-			printer,
-		)
-		if err == nil || apierrors.IsConflict(err) {
+	printer, err := s.modificationPrinter(parameters.Output, parameters.DryRun.Operation("stopped"), parameters.Wait)
+	if err != nil {
+		return err
+	}
+	audit := s.auditor.NewRecord(ctx, operationStreamStop, parameters.Audit, parameters.DryRun)
+	if parameters.Selection != nil {
+		return s.modifyStreamSelection(ctx, parameters.StreamClass, parameters.Namespace, parameters.Selection, true, audit, parameters.DryRun, parameters.Queue, printer)
+	}
+	err = s.modifyStreamDefinition(ctx,
+		parameters.Namespace,
+		parameters.StreamClass,
+		parameters.StreamId,
+		streamapis.Suspended,
+		func(def streamapis.Definition) error {
+			audit.Apply(def.ToUnstructured())
+			return def.SetSuspended(true)
+		},
+		func(definition streamapis.Definition) bool {
+			return !definition.Suspended()
+		},
+		parameters.DryRun,
+		printer,
+	)
+	if err != nil || !parameters.Wait {
+		return err
+	}
+
+	namespacedName := types.NamespacedName{Namespace: parameters.Namespace, Name: parameters.StreamId}
+	return s.waitForPhase(ctx, parameters.StreamClass, namespacedName, streamapis.Suspended, true, parameters.Timeout, parameters.Output)
+}
+
+// modifyStreamSelection sets the suspended flag on every stream of the selection through the execution queue
+func (s *stream) modifyStreamSelection(ctx context.Context,
+	streamClass string,
+	namespace string,
+	selection *models.StreamSelection,
+	suspended bool,
+	audit AuditRecord,
+	dryRun models.DryRunStrategy,
+	queue models.QueueParameters,
+	printer printers.ResourcePrinter) error {
+
+	membersPublisher, err := newStreamSelectionPublisher(s.clientProvider, streamClass, namespace, selection)
+	if err != nil {
+		return err
+	}
+	return s.executionQueue.ProcessQueue(ctx, newStreamSuspensionProcessor(suspended, audit), printer, membersPublisher, interfaces.QueueOptions{DryRun: dryRun, MaxAttempts: queue.MaxAttempts, Concurrency: queue.Concurrency, PrintSummary: true})
+}
+
+// List is a method that allows users to list streams in the cluster, optionally filtered by stream class and namespace
+func (s *stream) List(ctx context.Context, parameters *models.StreamListParameters) (cmdinterfaces.StreamInventory, error) {
+	var queuePublisher interfaces.QueuePublisher
+	selector := &client.MatchingLabelsSelector{}
+	if parameters.StreamClass == "" {
+		queuePublisher = publisher.NewAllStreamDefinitionsPublisher(s.clientProvider, parameters.Namespace, filter.NewAllowAll(), selector)
+	} else {
+		queuePublisher = publisher.NewStreamClassMembersPublisher(s.clientProvider, parameters.StreamClass, parameters.Namespace, filter.NewAllowAll(), selector)
+	}
+
+	processor := NewStreamInventoryProcessor()
+	err := s.executionQueue.ProcessQueue(ctx, processor, logging.Printer(""), queuePublisher, interfaces.QueueOptions{})
+	if err != nil { // coverage-ignore
+		return nil, err
+	}
+
+	return NewStreamInventory(processor.Entries), nil
+}
+
+// Describe is a method that allows users to inspect a stream together with its backfill requests, jobs, pods and events
+func (s *stream) Describe(ctx context.Context, parameters *models.StreamDescribeParameters) (cmdinterfaces.StreamDescription, error) {
+	clientSet, err := s.clientProvider.ProvideClientSet()
+	if err != nil {
+		return nil, fmt.Errorf("error providing client set: %w", err)
+	}
+	sc, err := clientSet.StreamingV1().StreamClasses("").Get(ctx, parameters.StreamClass, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching stream class: %w", err)
+	}
+
+	namespacedName := types.NamespacedName{Namespace: parameters.Namespace, Name: parameters.StreamId}
+	streamObject, err := s.reader.Read(ctx, sc, namespacedName)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching stream definition: %w", err)
+	}
+	streamDefinition, err := contracts.FromUnstructured(streamObject)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing stream definition: %w", err)
+	}
+
+	backfillRequests, err := clientSet.StreamingV1().BackfillRequests(parameters.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("spec.streamId=%s", parameters.StreamId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing backfill requests: %w", err)
+	}
+	var requests []v1.BackfillRequest
+	for _, bfr := range backfillRequests.Items {
+		if bfr.Spec.StreamClass == parameters.StreamClass {
+			requests = append(requests, bfr)
+		}
+	}
+
+	unstructuredClient, err := s.clientProvider.ProvideUnstructuredClient()
+	if err != nil {
+		return nil, fmt.Errorf("error providing unstructured client: %w", err)
+	}
+
+	jobs := &batchv1.JobList{}
+	err = unstructuredClient.List(ctx, jobs, client.InNamespace(parameters.Namespace), client.MatchingLabels{interfaces.StreamIdJobLabelKey: parameters.StreamId})
+	if err != nil {
+		return nil, fmt.Errorf("error listing jobs: %w", err)
+	}
+
+	involvedObjects := []string{parameters.StreamId}
+	var pods []corev1.Pod
+	for _, job := range jobs.Items {
+		if job.Spec.Selector == nil { // coverage-ignore
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+		if err != nil { // coverage-ignore
+			return nil, fmt.Errorf("error parsing job selector: %w", err)
+		}
+		podList := &corev1.PodList{}
+		err = unstructuredClient.List(ctx, podList, client.InNamespace(parameters.Namespace), client.MatchingLabelsSelector{Selector: selector})
+		if err != nil {
+			return nil, fmt.Errorf("error listing pods: %w", err)
+		}
+		for _, pod := range podList.Items {
+			pods = append(pods, pod)
+			involvedObjects = append(involvedObjects, pod.Name)
+		}
+	}
+
+	var events []corev1.Event
+	for _, name := range involvedObjects {
+		eventList := &corev1.EventList{}
+		err = unstructuredClient.List(ctx, eventList, client.InNamespace(parameters.Namespace), client.MatchingFields{"involvedObject.name": name})
+		if err != nil {
+			return nil, fmt.Errorf("error listing events: %w", err)
+		}
+		events = append(events, eventList.Items...)
+	}
+
+	return NewStreamDescription(sc.Name, streamDefinition, requests, jobs.Items, pods, events), nil
+}
+
+// waitForPhase polls the stream until it reaches the expected phase and, if requested, until its streaming job
+// has terminated. It returns an error if the stream does not reach the phase within the provided timeout.
+func (s *stream) waitForPhase(ctx context.Context,
+	streamClass string,
+	namespacedName types.NamespacedName,
+	expectedPhase streamapis.Phase,
+	waitForJob bool,
+	timeout time.Duration,
+	output string) error {
+	return waitForStreamPhase(ctx, s.clientProvider, s.reader, streamClass, namespacedName, expectedPhase, waitForJob, timeout, output)
+}
+
+// waitForStreamPhase implements waitForPhase for the services that wait for a stream they did not modify themselves.
+func waitForStreamPhase(ctx context.Context,
+	clientProvider cmdinterfaces.ClientProvider,
+	reader interfaces.UnstructuredReader,
+	streamClass string,
+	namespacedName types.NamespacedName,
+	expectedPhase streamapis.Phase,
+	waitForJob bool,
+	timeout time.Duration,
+	output string) error {
+
+	clientSet, err := clientProvider.ProvideClientSet()
+	if err != nil {
+		return fmt.Errorf("error providing client set: %w", err)
+	}
+	sc, err := clientSet.StreamingV1().StreamClasses("").Get(ctx, streamClass, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error fetching stream class: %w", err)
+	}
+
+	unstructuredClient, err := clientProvider.ProvideUnstructuredClient()
+	if err != nil {
+		return fmt.Errorf("error providing unstructured client: %w", err)
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var streamObject *unstructured.Unstructured
+	err = wait.PollUntilContextCancel(ctx, 1*time.Second, true, func(ctx context.Context) (done bool, err error) {
+		streamObject, err = reader.Read(ctx, sc, namespacedName)
+		if err != nil {
+			return false, fmt.Errorf("error fetching stream definition: %w", err)
+		}
+		definition, err := contracts.FromUnstructured(streamObject)
+		if err != nil {
+			return false, fmt.Errorf("error parsing stream definition: %w", err)
+		}
+		if definition.GetPhase() != expectedPhase {
+			return false, nil
+		}
+		if !waitForJob {
+			return true, nil
+		}
+
+		job := &batchv1.Job{}
+		err = unstructuredClient.Get(ctx, namespacedName, job)
+		if apierrors.IsNotFound(err) {
 			return true, nil
 		}
-		return false, err
+		if err != nil {
+			return false, fmt.Errorf("error fetching streaming job: %w", err)
+		}
+		return isJobFinished(job), nil
 	})
+	if wait.Interrupted(err) && ctx.Err() == context.DeadlineExceeded {
+		return errors.NewTimeoutError(fmt.Errorf("timed out after %s waiting for stream %s to reach phase %s", timeout, namespacedName, expectedPhase))
+	}
+	if err != nil {
+		return err
+	}
+
+	printer, err := logging.NewPrinter(output, strings.ToLower(string(expectedPhase)))
+	if err != nil { // coverage-ignore (validated by the command parameters)
+		return err
+	}
+	return printer.PrintObj(streamObject, os.Stdout)
+}
+
+// modificationPrinter returns the printer for modified streams. If the command waits for the stream and prints
+// structured output, only the final state of the stream is printed by waitForPhase, so the output stays a single document.
+func (s *stream) modificationPrinter(output string, operation string, wait bool) (printers.ResourcePrinter, error) {
+	if wait && logging.IsStructuredOutput(output) {
+		return printers.ResourcePrinterFunc(func(runtime.Object, io.Writer) error { return nil }), nil
+	}
+	return logging.NewPrinter(output, operation)
 }
 
 func (s *stream) modifyStreamDefinition(ctx context.Context,
@@ -90,7 +338,9 @@ func (s *stream) modifyStreamDefinition(ctx context.Context,
 	streamId string,
 	expectedPhase streamapis.Phase,
 	modifier func(streamapis.Definition) error,
-	needModify func(streamapis.Definition) bool, printer printers.ResourcePrinter) error {
+	needModify func(streamapis.Definition) bool,
+	dryRun models.DryRunStrategy,
+	printer printers.ResourcePrinter) error {
 
 	clientSet, err := s.clientProvider.ProvideClientSet()
 	if err != nil {
@@ -107,23 +357,42 @@ func (s *stream) modifyStreamDefinition(ctx context.Context,
 		return fmt.Errorf("error providing unstructured client: %w", err)
 	}
 
-	streamDefinition, err := streamapis.GetStreamForClass(ctx, unstructuredClient, sc, namespacedName, contracts.FromUnstructured)
-	if err != nil {
-		return fmt.Errorf("error fetching stream definition: %w", err)
-	}
+	// The stream is re-read and the modification is re-applied on every attempt, so an update that conflicts with
+	// a concurrent change (e.g. a status update from the operator) is retried on top of the latest version.
+	var streamDefinition streamapis.Definition
+	err = retry.RetryOnConflict(conflictRetryBackoff, func() error {
+		streamDefinition, err = streamapis.GetStreamForClass(ctx, unstructuredClient, sc, namespacedName, contracts.FromUnstructured)
+		if err != nil {
+			return fmt.Errorf("error fetching stream definition: %w", err)
+		}
 
-	if !needModify(streamDefinition) {
-		return errors.NewStatusNoOpError(expectedPhase, namespacedName)
-	}
+		if !needModify(streamDefinition) {
+			return errors.NewStatusNoOpError(expectedPhase, namespacedName)
+		}
 
-	err = modifier(streamDefinition)
-	if err != nil {
-		return fmt.Errorf("error modifiing stream definition: %w", err)
-	}
+		err = modifier(streamDefinition)
+		if err != nil {
+			return fmt.Errorf("error modifiing stream definition: %w", err)
+		}
 
-	err = unstructuredClient.Update(ctx, streamDefinition.ToUnstructured())
+		switch dryRun {
+		case models.DryRunClient:
+			return nil
+		case models.DryRunServer:
+			err = unstructuredClient.Update(ctx, streamDefinition.ToUnstructured(), client.DryRunAll)
+		default:
+			err = unstructuredClient.Update(ctx, streamDefinition.ToUnstructured())
+		}
+		if err != nil {
+			return fmt.Errorf("error updating stream definition: %w", err)
+		}
+		return nil
+	})
+	if apierrors.IsConflict(err) {
+		return fmt.Errorf("stream %s was modified concurrently, giving up after %d attempts: %w", namespacedName, conflictRetryBackoff.Steps, err)
+	}
 	if err != nil {
-		return fmt.Errorf("error updating stream definition: %w", err)
+		return err
 	}
 
 	err = printer.PrintObj(streamDefinition.ToUnstructured(), os.Stdout)
@@ -132,3 +401,13 @@ func (s *stream) modifyStreamDefinition(ctx context.Context,
 	}
 	return nil
 }
+
+// isJobFinished returns true if the job has a Complete or Failed condition.
+func isJobFinished(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	v1 "github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
+	"github.com/SneaksAndData/arcane-operator/pkg/generated/clientset/versioned"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/logging"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ interfaces.UnstructuredProcessor = (*streamBackfillProcessor)(nil)
+
+// Results of the backfill of a single stream of a selection.
+const (
+	backfillResultCreated    = "created"
+	backfillResultSkipped    = "skipped"
+	backfillResultSucceeded  = "succeeded"
+	backfillResultFailed     = "failed"
+	backfillResultUnfinished = "unfinished"
+)
+
+// streamBackfillResult is the result of the backfill of a single stream of a selection.
+type streamBackfillResult struct {
+	Stream types.NamespacedName
+	Result string
+	Error  string
+}
+
+// streamBackfillProcessor creates a backfill request for each stream of a selection. When waiting for the backfills,
+// at most maxInFlight of them run at the same time, and the processor blocks until one of them completes before it
+// creates the next request. The stream definitions are not modified, so the processor never returns an update.
+type streamBackfillProcessor struct {
+	backfill   *backfill
+	clientSet  *versioned.Clientset
+	parameters *models.BackfillParameters
+	wait       bool
+	inFlight   chan struct{}
+
+	running sync.WaitGroup
+	lock    sync.Mutex
+	results []streamBackfillResult
+}
+
+func newStreamBackfillProcessor(backfill *backfill, clientSet *versioned.Clientset, parameters *models.BackfillParameters) *streamBackfillProcessor {
+	processor := &streamBackfillProcessor{
+		backfill:   backfill,
+		clientSet:  clientSet,
+		parameters: parameters,
+		wait:       parameters.Wait || parameters.MaxInFlight > 0,
+	}
+	if parameters.MaxInFlight > 0 {
+		processor.inFlight = make(chan struct{}, parameters.MaxInFlight)
+	}
+	return processor
+}
+
+func (p *streamBackfillProcessor) Process(ctx context.Context, stream *unstructured.Unstructured, _ *v1.StreamClass) (*unstructured.Unstructured, bool, error) {
+	def := types.NamespacedName{Namespace: stream.GetNamespace(), Name: stream.GetName()}
+	if p.inFlight != nil {
+		select {
+		case p.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			p.record(def, backfillResultUnfinished, ctx.Err().Error())
+			return nil, false, nil
+		}
+	}
+
+	parameters := *p.parameters
+	parameters.StreamId = def.Name
+	parameters.Namespace = def.Namespace
+	parameters.Selection = nil
+
+	bfr, created, err := p.backfill.submit(ctx, p.clientSet, &parameters)
+	switch {
+	case err != nil:
+		p.release()
+		p.record(def, backfillResultFailed, err.Error())
+		return nil, false, nil
+	case !created:
+		p.release()
+		p.record(def, backfillResultSkipped, "active backfill request "+bfr.Name+" already exists")
+		p.print(bfr, "already exists")
+		return nil, false, nil
+	case !p.wait:
+		p.release()
+		p.record(def, backfillResultCreated, "")
+		p.print(bfr, parameters.DryRun.Operation("created"))
+		return nil, false, nil
+	}
+
+	if !logging.IsStructuredOutput(parameters.Output) {
+		p.print(bfr, "started")
+	}
+	p.running.Go(func() {
+		defer p.release()
+		p.waitForBackfill(ctx, def, bfr)
+	})
+	return nil, false, nil
+}
+
+// waitForBackfill waits for the backfill request to complete and records its outcome.
+func (p *streamBackfillProcessor) waitForBackfill(ctx context.Context, def types.NamespacedName, bfr *v1.BackfillRequest) {
+	completed, err := p.backfill.waitForCompletion(ctx, p.clientSet, bfr)
+	if ctx.Err() != nil {
+		p.record(def, backfillResultUnfinished, ctx.Err().Error())
+		return
+	}
+	if err != nil {
+		p.record(def, backfillResultFailed, err.Error())
+		return
+	}
+
+	outcome, err := p.backfill.resolveBackfillOutcome(ctx, p.clientSet, p.parameters.StreamClass, completed)
+	if err != nil {
+		p.record(def, backfillResultFailed, fmt.Sprintf("error resolving the outcome of backfill request %s: %v", completed.Name, err))
+		return
+	}
+
+	elapsed := outcome.Elapsed.Round(time.Second)
+	if !outcome.Succeeded {
+		p.record(def, backfillResultFailed, outcome.LastError)
+		p.print(completed, fmt.Sprintf("failed after %s", elapsed))
+		return
+	}
+	p.record(def, backfillResultSucceeded, "")
+	p.print(completed, fmt.Sprintf("succeeded in %s", elapsed))
+}
+
+// Wait blocks until all backfills the processor waits for are completed and returns the results ordered by stream.
+func (p *streamBackfillProcessor) Wait() []streamBackfillResult {
+	p.running.Wait()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	sort.SliceStable(p.results, func(i, j int) bool {
+		return p.results[i].Stream.String() < p.results[j].Stream.String()
+	})
+	return p.results
+}
+
+func (p *streamBackfillProcessor) release() {
+	if p.inFlight != nil {
+		<-p.inFlight
+	}
+}
+
+func (p *streamBackfillProcessor) record(def types.NamespacedName, result string, message string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.results = append(p.results, streamBackfillResult{Stream: def, Result: result, Error: message})
+}
+
+// print prints the backfill request, serialized so the output of concurrent backfills does not interleave.
+func (p *streamBackfillProcessor) print(bfr *v1.BackfillRequest, operation string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	err := p.backfill.print(bfr, p.parameters.Output, operation)
+	if err != nil { // coverage-ignore (validated by the command parameters)
+		logging.LogError(withBackfillRequestKind(bfr), "printing backfill request", err)
+	}
+}
+
+// printBackfillSummary prints the number of backfills per result, followed by the streams that did not succeed.
+func printBackfillSummary(w io.Writer, results []streamBackfillResult) error {
+	counts := map[string]int{}
+	for _, result := range results {
+		counts[result.Result]++
+	}
+
+	_, err := fmt.Fprintf(w, "Backfill summary: %d streams, %d succeeded, %d failed, %d created, %d skipped, %d unfinished\n",
+		len(results),
+		counts[backfillResultSucceeded],
+		counts[backfillResultFailed],
+		counts[backfillResultCreated],
+		counts[backfillResultSkipped],
+		counts[backfillResultUnfinished])
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if result.Error == "" {
+			continue
+		}
+		_, err = fmt.Fprintf(w, "  %s %s: %s\n", result.Stream, result.Result, result.Error)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	v1 "github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
+	streamapis "github.com/SneaksAndData/arcane-operator/services/controllers/stream"
+	"github.com/SneaksAndData/arcane-operator/services/job"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
+	svcinterfaces "github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+var _ interfaces.StreamDescription = (*StreamDescription)(nil)
+
+// maxRecentBackfillRequests limits the number of backfill requests included in the stream description.
+const maxRecentBackfillRequests = 10
+
+type StreamDescription struct {
+	streamClass      string
+	definition       streamapis.Definition
+	backfillRequests []v1.BackfillRequest
+	jobs             []batchv1.Job
+	pods             []corev1.Pod
+	events           []corev1.Event
+}
+
+func NewStreamDescription(streamClass string,
+	definition streamapis.Definition,
+	backfillRequests []v1.BackfillRequest,
+	jobs []batchv1.Job,
+	pods []corev1.Pod,
+	events []corev1.Event) *StreamDescription {
+
+	// Show the most recent backfill requests first
+	sort.SliceStable(backfillRequests, func(i, j int) bool {
+		return backfillRequests[j].CreationTimestamp.Before(&backfillRequests[i].CreationTimestamp)
+	})
+	if len(backfillRequests) > maxRecentBackfillRequests {
+		backfillRequests = backfillRequests[:maxRecentBackfillRequests]
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+
+	return &StreamDescription{
+		streamClass:      streamClass,
+		definition:       definition,
+		backfillRequests: backfillRequests,
+		jobs:             jobs,
+		pods:             pods,
+		events:           events,
+	}
+}
+
+func (d *StreamDescription) Raw() *unstructured.Unstructured {
+	return d.definition.ToUnstructured()
+}
+
+func (d *StreamDescription) Print(w io.Writer) error { // coverage-ignore (tested in integration tests)
+	stream := d.definition.ToUnstructured()
+	downtimeKey, downtimeAge := downtimeInfo(stream)
+	out := &prefixWriter{writer: tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)}
+
+	out.write(0, "Name:\t%s\n", stream.GetName())
+	out.write(0, "Namespace:\t%s\n", stream.GetNamespace())
+	out.write(0, "Stream Class:\t%s\n", d.streamClass)
+	out.write(0, "Kind:\t%s\n", stream.GetKind())
+	out.write(0, "Phase:\t%s\n", d.definition.GetPhase())
+	out.write(0, "Suspended:\t%t\n", d.definition.Suspended())
+	out.write(0, "Downtime Key:\t%s\n", downtimeKey)
+	out.write(0, "Downtime Age:\t%s\n", downtimeAge)
+	annotations := stream.GetAnnotations()
+	out.write(0, "Last Modified:\n")
+	out.write(1, "Operation:\t%s\n", annotationOrNone(annotations, svcinterfaces.LastOperationAnnotationKey))
+	out.write(1, "By:\t%s\n", annotationOrNone(annotations, svcinterfaces.LastModifiedByAnnotationKey))
+	out.write(1, "At:\t%s\n", annotationOrNone(annotations, svcinterfaces.LastModifiedAtAnnotationKey))
+	out.write(1, "Reason:\t%s\n", annotationOrNone(annotations, svcinterfaces.ReasonAnnotationKey))
+	out.write(1, "Ticket:\t%s\n", annotationOrNone(annotations, svcinterfaces.TicketAnnotationKey))
+	out.write(0, "Job Templates:\n")
+	out.write(1, "Streaming:\t%s\n", d.definition.GetJobTemplate(nil))
+	out.write(1, "Backfill:\t%s\n", d.definition.GetJobTemplate(&v1.BackfillRequest{}))
+
+	out.write(0, "Backfill Requests:\n")
+	if len(d.backfillRequests) == 0 {
+		out.write(1, "<none>\n")
+	} else {
+		out.write(1, "Name\tCompleted\tRequested By\tReason\tAge\n")
+		out.write(1, "----\t---------\t------------\t------\t---\n")
+		for _, bfr := range d.backfillRequests {
+			requestedBy := annotationOrNone(bfr.Annotations, svcinterfaces.LastModifiedByAnnotationKey)
+			reason := annotationOrNone(bfr.Annotations, svcinterfaces.ReasonAnnotationKey)
+			out.write(1, "%s\t%t\t%s\t%s\t%s\n", bfr.Name, bfr.Spec.Completed, requestedBy, reason, age(bfr.CreationTimestamp))
+		}
+	}
+
+	out.write(0, "Jobs:\n")
+	if len(d.jobs) == 0 {
+		out.write(1, "<none>\n")
+	} else {
+		out.write(1, "Name\tBackfill\tActive\tSucceeded\tFailed\tAge\n")
+		out.write(1, "----\t--------\t------\t---------\t------\t---\n")
+		for _, j := range d.jobs {
+			backfilling := j.Labels[job.BackfillLabel]
+			if backfilling == "" {
+				backfilling = "false"
+			}
+			out.write(1, "%s\t%s\t%d\t%d\t%d\t%s\n", j.Name, backfilling, j.Status.Active, j.Status.Succeeded, j.Status.Failed, age(j.CreationTimestamp))
+		}
+	}
+
+	out.write(0, "Pods:\n")
+	if len(d.pods) == 0 {
+		out.write(1, "<none>\n")
+	} else {
+		out.write(1, "Name\tPhase\tRestarts\tAge\n")
+		out.write(1, "----\t-----\t--------\t---\n")
+		for _, pod := range d.pods {
+			var restarts int32
+			for _, status := range pod.Status.ContainerStatuses {
+				restarts += status.RestartCount
+			}
+			out.write(1, "%s\t%s\t%d\t%s\n", pod.Name, pod.Status.Phase, restarts, age(pod.CreationTimestamp))
+		}
+	}
+
+	if len(d.events) == 0 {
+		out.write(0, "Events:\t<none>\n")
+	} else {
+		out.write(0, "Events:\n")
+		out.write(1, "Type\tReason\tAge\tObject\tMessage\n")
+		out.write(1, "----\t------\t---\t------\t-------\n")
+		for _, event := range d.events {
+			object := fmt.Sprintf("%s/%s", strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name)
+			out.write(1, "%s\t%s\t%s\t%s\t%s\n", event.Type, event.Reason, age(metav1.NewTime(eventTime(event))), object, strings.TrimSpace(event.Message))
+		}
+	}
+
+	return out.flush()
+}
+
+// prefixWriter writes tab-separated lines with kubectl describe style indentation.
+type prefixWriter struct {
+	writer *tabwriter.Writer
+	err    error
+}
+
+func (p *prefixWriter) write(level int, format string, a ...interface{}) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = fmt.Fprintf(p.writer, strings.Repeat("  ", level)+format, a...)
+}
+
+func (p *prefixWriter) flush() error {
+	if p.err != nil {
+		return p.err
+	}
+	return p.writer.Flush()
+}
+
+func age(timestamp metav1.Time) string {
+	if timestamp.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(time.Since(timestamp.Time))
+}
+
+func eventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
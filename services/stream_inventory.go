@@ -0,0 +1,133 @@
+package services
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	streamapis "github.com/SneaksAndData/arcane-operator/services/controllers/stream"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/filter"
+	svcinterfaces "github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+var _ interfaces.StreamInventory = (*StreamInventory)(nil)
+
+// StreamInventoryEntry is a single stream definition together with the name of the stream class it belongs to.
+type StreamInventoryEntry struct {
+	StreamClass string
+	Definition  streamapis.Definition
+}
+
+type StreamInventory struct {
+	entries []StreamInventoryEntry
+}
+
+func NewStreamInventory(entries []StreamInventoryEntry) *StreamInventory {
+	sorted := make([]StreamInventoryEntry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		left, right := sorted[i].Definition.NamespacedName(), sorted[j].Definition.NamespacedName()
+		if sorted[i].StreamClass != sorted[j].StreamClass {
+			return sorted[i].StreamClass < sorted[j].StreamClass
+		}
+		if left.Namespace != right.Namespace {
+			return left.Namespace < right.Namespace
+		}
+		return left.Name < right.Name
+	})
+	return &StreamInventory{entries: sorted}
+}
+
+func (s *StreamInventory) Table() *metav1.Table { // coverage-ignore (tested in integration tests)
+	table := &metav1.Table{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Table",
+			APIVersion: "meta.k8s.io/v1",
+		},
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Class", Type: "string"},
+			{Name: "Namespace", Type: "string"},
+			{Name: "Name", Type: "string"},
+			{Name: "Phase", Type: "string"},
+			{Name: "Suspended", Type: "boolean"},
+			{Name: "Downtime Key", Type: "string"},
+			{Name: "Downtime Age", Type: "string"},
+			{Name: "Kind", Type: "string", Priority: 1},
+			{Name: "Last Operation", Type: "string", Priority: 1},
+			{Name: "Modified By", Type: "string", Priority: 1},
+			{Name: "Reason", Type: "string", Priority: 1},
+		},
+	}
+
+	for _, entry := range s.entries {
+		stream := entry.Definition.ToUnstructured()
+		downtimeKey, downtimeAge := downtimeInfo(stream)
+		annotations := stream.GetAnnotations()
+		row := metav1.TableRow{
+			Cells: []interface{}{
+				entry.StreamClass,
+				stream.GetNamespace(),
+				stream.GetName(),
+				string(entry.Definition.GetPhase()),
+				strconv.FormatBool(entry.Definition.Suspended()),
+				downtimeKey,
+				downtimeAge,
+				stream.GetKind(),
+				annotationOrNone(annotations, svcinterfaces.LastOperationAnnotationKey),
+				annotationOrNone(annotations, svcinterfaces.LastModifiedByAnnotationKey),
+				annotationOrNone(annotations, svcinterfaces.ReasonAnnotationKey),
+			},
+		}
+		table.Rows = append(table.Rows, row)
+	}
+
+	return table
+}
+
+func (s *StreamInventory) Raw() []*unstructured.Unstructured {
+	items := make([]*unstructured.Unstructured, 0, len(s.entries))
+	for _, entry := range s.entries {
+		items = append(items, entry.Definition.ToUnstructured())
+	}
+	return items
+}
+
+func (s *StreamInventory) Objects() *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetAPIVersion("v1")
+	list.SetKind("List")
+	for _, item := range s.Raw() {
+		list.Items = append(list.Items, *item)
+	}
+	return list
+}
+
+// downtimeInfo returns the comma-separated downtime keys of the stream and the time passed since the downtime was
+// declared, or "<none>" placeholders if the stream is not in downtime.
+func downtimeInfo(stream *unstructured.Unstructured) (string, string) {
+	keys := filter.DowntimeKeys(stream)
+	if len(keys) == 0 {
+		return "<none>", "<none>"
+	}
+	key := strings.Join(keys, ",")
+
+	begin, err := time.ParseInLocation(time.RFC3339, stream.GetAnnotations()[svcinterfaces.DowntimeBeginAnnotationKey], time.UTC)
+	if err != nil {
+		return key, "<unknown>"
+	}
+
+	return key, duration.HumanDuration(time.Since(begin))
+}
+
+// annotationOrNone returns the value of the annotation, or a "<none>" placeholder if the annotation is not set.
+func annotationOrNone(annotations map[string]string, key string) string {
+	if value, ok := annotations[key]; ok && value != "" {
+		return value
+	}
+	return "<none>"
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	v1 "github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
+	"github.com/SneaksAndData/arcane-operator/services/controllers/contracts"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ interfaces.UnstructuredProcessor = (*StreamInventoryProcessor)(nil)
+
+// StreamInventoryProcessor collects the stream definitions published to the queue without modifying them, together
+// with the name of their stream class.
+type StreamInventoryProcessor struct {
+	Entries []StreamInventoryEntry
+
+	lock sync.Mutex
+}
+
+func NewStreamInventoryProcessor() *StreamInventoryProcessor {
+	return &StreamInventoryProcessor{}
+}
+
+func (s *StreamInventoryProcessor) Process(_ context.Context, stream *unstructured.Unstructured, class *v1.StreamClass) (*unstructured.Unstructured, bool, error) {
+	definition, err := contracts.FromUnstructured(stream)
+	if err != nil { // coverage-ignore
+		return nil, false, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.Entries = append(s.Entries, StreamInventoryEntry{
+		StreamClass: class.Name,
+		Definition:  definition,
+	})
+
+	// We return nil here because we don't want to modify the original object, we just want to collect it
+	return nil, false, nil
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	streamapis "github.com/SneaksAndData/arcane-operator/services/controllers/stream"
+	cmdinterfaces "github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/errors"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/logging"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// rebuildSteps is the number of steps of the rebuild workflow: stop, backfill and start.
+const rebuildSteps = 3
+
+var _ cmdinterfaces.StreamRebuildService = (*streamRebuild)(nil)
+
+// streamRebuild is a service that orchestrates the stream and backfill services to re-load a stream.
+type streamRebuild struct {
+	streamService   cmdinterfaces.StreamService
+	backfillService cmdinterfaces.BackfillService
+	clientProvider  cmdinterfaces.ClientProvider
+	reader          interfaces.UnstructuredReader
+}
+
+// NewStreamRebuildService creates a new instance of the streamRebuild, which stops, backfills and starts a stream.
+func NewStreamRebuildService(streamService cmdinterfaces.StreamService,
+	backfillService cmdinterfaces.BackfillService,
+	clientProvider cmdinterfaces.ClientProvider,
+	reader interfaces.UnstructuredReader) cmdinterfaces.StreamRebuildService {
+	return &streamRebuild{
+		streamService:   streamService,
+		backfillService: backfillService,
+		clientProvider:  clientProvider,
+		reader:          reader,
+	}
+}
+
+// Rebuild is a method that allows users to stop a stream, backfill it and start it again in one command. The operator
+// does not run the backfill of a suspended stream, so the stream stopped by the first step is resumed right before the
+// backfill request is created and the operator keeps its streaming job stopped until the backfill completes.
+func (r *streamRebuild) Rebuild(ctx context.Context, parameters *models.RebuildParameters) error {
+	name := types.NamespacedName{Namespace: parameters.Namespace, Name: parameters.StreamId}
+
+	logging.LogStep(1, rebuildSteps, fmt.Sprintf("Stopping stream %s", name))
+	wasSuspended := false
+	err := r.streamService.Stop(ctx, parameters.ToStopParameters(true))
+	var noOpErr *errors.StatusNoOpError
+	switch {
+	case stderrors.As(err, &noOpErr):
+		wasSuspended = true
+	case err != nil:
+		return r.rollback(ctx, parameters, 1, wasSuspended, fmt.Errorf("error stopping stream %s: %w", name, err))
+	}
+
+	logging.LogStep(2, rebuildSteps, fmt.Sprintf("Backfilling stream %s", name))
+	err = r.streamService.Start(ctx, parameters.ToStartParameters(false))
+	if err != nil && !stderrors.As(err, &noOpErr) {
+		return r.rollback(ctx, parameters, 2, wasSuspended, fmt.Errorf("error resuming stream %s for the backfill: %w", name, err))
+	}
+	err = r.backfillService.Backfill(ctx, parameters.ToBackfillParameters())
+	if err != nil {
+		return r.rollback(ctx, parameters, 2, wasSuspended, fmt.Errorf("error backfilling stream %s: %w", name, err))
+	}
+
+	logging.LogStep(3, rebuildSteps, fmt.Sprintf("Starting stream %s", name))
+	err = r.streamService.Start(ctx, parameters.ToStartParameters(true))
+	if stderrors.As(err, &noOpErr) {
+		// The stream was resumed for the backfill, so only its return to the Running phase is awaited
+		err = waitForStreamPhase(ctx, r.clientProvider, r.reader, parameters.StreamClass, name, streamapis.Running, false, parameters.StreamTimeout, parameters.Output)
+	}
+	if err != nil {
+		return r.rollback(ctx, parameters, 3, wasSuspended, fmt.Errorf("error starting stream %s after the backfill completed: %w", name, err))
+	}
+	return nil
+}
+
+// rollback returns the stream to its original suspension state after the step failed. A stream that was running
+// before the rebuild is started again. The backfill of a stream that was suspended before the rebuild is cancelled
+// before the stream is stopped again, as the operator would otherwise keep starting the backfill of the suspended
+// stream. The rollback is not cancelled with the command, so an interrupted rebuild does not leave the stream in a
+// different suspension state.
+func (r *streamRebuild) rollback(ctx context.Context, parameters *models.RebuildParameters, step int, wasSuspended bool, cause error) error {
+	rollbackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), parameters.StreamTimeout)
+	defer cancel()
+
+	var noOpErr *errors.StatusNoOpError
+	if !wasSuspended {
+		logging.LogStep(step, rebuildSteps, fmt.Sprintf("Rolling back: starting stream %s/%s", parameters.Namespace, parameters.StreamId))
+		err := r.streamService.Start(rollbackCtx, parameters.ToStartParameters(false))
+		if err != nil && !stderrors.As(err, &noOpErr) {
+			return fmt.Errorf("%w, rollback failed: %v", cause, err)
+		}
+		return cause
+	}
+
+	logging.LogStep(step, rebuildSteps, fmt.Sprintf("Rolling back: cancelling the backfill and stopping stream %s/%s", parameters.Namespace, parameters.StreamId))
+	err := r.backfillService.Cancel(rollbackCtx, parameters.ToBackfillCancelParameters())
+	var noBackfillErr *errors.NoActiveBackfillError
+	if err != nil && !stderrors.As(err, &noBackfillErr) {
+		return fmt.Errorf("%w, rollback failed: %v", cause, err)
+	}
+
+	err = r.streamService.Stop(rollbackCtx, parameters.ToStopParameters(false))
+	if err != nil && !stderrors.As(err, &noOpErr) {
+		return fmt.Errorf("%w, rollback failed: %v", cause, err)
+	}
+	return cause
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"fmt"
+
+	cmdinterfaces "github.com/sneaksAndData/kubectl-plugin-arcane/commands/interfaces"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/filter"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/publisher"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// newStreamSelectionPublisher creates a publisher of the streams of the stream class that match the selection.
+func newStreamSelectionPublisher(clientProvider cmdinterfaces.ClientProvider, streamClass string, namespace string, selection *models.StreamSelection) (interfaces.QueuePublisher, error) {
+	selector, err := labels.Parse(selection.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing label selector: %w", err)
+	}
+
+	var objectFilter interfaces.ObjectFilter
+	switch {
+	case len(selection.StreamIds) > 0:
+		objectFilter = filter.NewByNames(selection.StreamIds)
+	case selection.Prefix != "":
+		objectFilter = filter.NewByNamePrefix(selection.Prefix)
+	default:
+		objectFilter = filter.NewAllowAll()
+	}
+
+	return publisher.NewStreamClassMembersPublisher(clientProvider, streamClass, namespace, objectFilter, &client.MatchingLabelsSelector{Selector: selector}), nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+
+	v1 "github.com/SneaksAndData/arcane-operator/pkg/apis/streaming/v1"
+	"github.com/SneaksAndData/arcane-operator/services/controllers/contracts"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ interfaces.UnstructuredProcessor = (*streamSuspensionProcessor)(nil)
+
+// streamSuspensionProcessor sets the suspended flag of each stream to the desired value, skipping streams that
+// already have it.
+type streamSuspensionProcessor struct {
+	suspended bool
+	audit     AuditRecord
+}
+
+func newStreamSuspensionProcessor(suspended bool, audit AuditRecord) *streamSuspensionProcessor {
+	return &streamSuspensionProcessor{
+		suspended: suspended,
+		audit:     audit,
+	}
+}
+
+func (s *streamSuspensionProcessor) Process(_ context.Context, stream *unstructured.Unstructured, class *v1.StreamClass) (*unstructured.Unstructured, bool, error) {
+	definition, err := contracts.FromUnstructured(stream)
+	if err != nil { // coverage-ignore
+		return nil, false, err
+	}
+
+	if definition.Suspended() == s.suspended {
+		return nil, false, nil // Skip streams that already have the desired state
+	}
+
+	s.audit.Apply(stream)
+	err = definition.SetSuspended(s.suspended)
+	if err != nil { // coverage-ignore
+		return nil, false, err
+	}
+	return definition.ToUnstructured(), true, nil
+}
@@ -1,12 +1,23 @@
 package services
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	streamapis "github.com/SneaksAndData/arcane-operator/services/controllers/stream"
+	mockv1 "github.com/SneaksAndData/arcane-stream-mock/pkg/apis/streaming/v1"
 	"github.com/sneaksAndData/kubectl-plugin-arcane/commands/models"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/errors"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/services/interfaces"
+	"github.com/sneaksAndData/kubectl-plugin-arcane/tests/helpers"
 	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	versionedv1 "github.com/SneaksAndData/arcane-operator/pkg/generated/clientset/versioned"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -23,7 +34,8 @@ func Test_StreamStarted(t *testing.T) {
 	c, err := client.New(kubeConfig, client.Options{})
 	require.NoError(t, err)
 
-	streamService := NewStreamService(NewFakeClientProvider(streamingClientSet, c))
+	clientProvider := NewFakeClientProvider(streamingClientSet, c)
+	streamService := NewStreamService(clientProvider, NewUnstructuredReader(clientProvider))
 	err = streamService.Start(t.Context(), &models.StartParameters{
 		Namespace:   "default",
 		StreamId:    name,
@@ -46,7 +58,8 @@ func Test_StreamStarted_Error(t *testing.T) {
 	c, err := client.New(kubeConfig, client.Options{})
 	require.NoError(t, err)
 
-	streamService := NewStreamService(NewFakeClientProvider(streamingClientSet, c))
+	clientProvider := NewFakeClientProvider(streamingClientSet, c)
+	streamService := NewStreamService(clientProvider, NewUnstructuredReader(clientProvider))
 	err = streamService.Start(t.Context(), &models.StartParameters{
 		Namespace:   "default",
 		StreamId:    name,
@@ -66,7 +79,8 @@ func Test_StreamStopped(t *testing.T) {
 	c, err := client.New(kubeConfig, client.Options{})
 	require.NoError(t, err)
 
-	streamService := NewStreamService(NewFakeClientProvider(streamingClientSet, c))
+	clientProvider := NewFakeClientProvider(streamingClientSet, c)
+	streamService := NewStreamService(clientProvider, NewUnstructuredReader(clientProvider))
 	err = streamService.Stop(t.Context(), &models.StopParameters{
 		Namespace:   "default",
 		StreamId:    name,
@@ -89,7 +103,8 @@ func Test_StreamStopped_Error(t *testing.T) {
 	c, err := client.New(kubeConfig, client.Options{})
 	require.NoError(t, err)
 
-	streamService := NewStreamService(NewFakeClientProvider(streamingClientSet, c))
+	clientProvider := NewFakeClientProvider(streamingClientSet, c)
+	streamService := NewStreamService(clientProvider, NewUnstructuredReader(clientProvider))
 	err = streamService.Stop(t.Context(), &models.StopParameters{
 		Namespace:   "default",
 		StreamId:    name,
@@ -98,3 +113,350 @@ func Test_StreamStopped_Error(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "Stream already has desired phase Suspended")
 }
+
+func Test_StreamList(t *testing.T) {
+	name := createTestStreamDefinition(t, false, "15s", true)
+	require.NotEmpty(t, name)
+	err := waitForPhase(t, name, streamapis.Suspended)
+	require.NoError(t, err)
+
+	streamingClientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+	c, err := client.New(kubeConfig, client.Options{})
+	require.NoError(t, err)
+
+	clientProvider := NewFakeClientProvider(streamingClientSet, c)
+	streamService := NewStreamService(clientProvider, NewUnstructuredReader(clientProvider))
+	inventory, err := streamService.List(t.Context(), &models.StreamListParameters{
+		Namespace:   "default",
+		StreamClass: "arcane-stream-mock",
+	})
+	require.NoError(t, err)
+
+	var found bool
+	for _, item := range inventory.Raw() {
+		require.Equal(t, "default", item.GetNamespace())
+		if item.GetName() == name {
+			found = true
+		}
+	}
+	require.True(t, found)
+	require.Len(t, inventory.Table().Rows, len(inventory.Raw()))
+}
+
+func Test_StreamDescribe(t *testing.T) {
+	name := createTestStreamDefinition(t, false, "15s", false)
+	require.NotEmpty(t, name)
+	err := waitForPhase(t, name, streamapis.Running)
+	require.NoError(t, err)
+
+	streamingClientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+	c, err := client.New(kubeConfig, client.Options{})
+	require.NoError(t, err)
+
+	clientProvider := NewFakeClientProvider(streamingClientSet, c)
+	streamService := NewStreamService(clientProvider, NewUnstructuredReader(clientProvider))
+	description, err := streamService.Describe(t.Context(), &models.StreamDescribeParameters{
+		Namespace:   "default",
+		StreamId:    name,
+		StreamClass: "arcane-stream-mock",
+	})
+	require.NoError(t, err)
+	require.Equal(t, name, description.Raw().GetName())
+
+	var output strings.Builder
+	err = description.Print(&output)
+	require.NoError(t, err)
+	require.Contains(t, output.String(), name)
+	require.Contains(t, output.String(), "Running")
+}
+
+func Test_StreamStopped_Wait(t *testing.T) {
+	name := createTestStreamDefinition(t, false, "15s", false)
+	require.NotEmpty(t, name)
+	err := waitForPhase(t, name, streamapis.Running)
+	require.NoError(t, err)
+
+	streamingClientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+	c, err := client.New(kubeConfig, client.Options{})
+	require.NoError(t, err)
+
+	clientProvider := NewFakeClientProvider(streamingClientSet, c)
+	streamService := NewStreamService(clientProvider, NewUnstructuredReader(clientProvider))
+	err = streamService.Stop(t.Context(), &models.StopParameters{
+		Namespace:   "default",
+		StreamId:    name,
+		StreamClass: "arcane-stream-mock",
+		Wait:        true,
+		Timeout:     2 * time.Minute,
+	})
+	require.NoError(t, err)
+
+	stream, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, string(streamapis.Suspended), stream.Status.Phase)
+}
+
+func Test_StreamStarted_WaitTimeout(t *testing.T) {
+	name := createTestStreamDefinition(t, false, "15s", true)
+	require.NotEmpty(t, name)
+	err := waitForPhase(t, name, streamapis.Suspended)
+	require.NoError(t, err)
+
+	streamingClientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+	c, err := client.New(kubeConfig, client.Options{})
+	require.NoError(t, err)
+
+	clientProvider := NewFakeClientProvider(streamingClientSet, c)
+	streamService := NewStreamService(clientProvider, NewUnstructuredReader(clientProvider))
+	err = streamService.Start(t.Context(), &models.StartParameters{
+		Namespace:   "default",
+		StreamId:    name,
+		StreamClass: "arcane-stream-mock",
+		Wait:        true,
+		Timeout:     time.Millisecond,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "timed out")
+}
+
+func Test_StreamStopped_Bulk(t *testing.T) {
+	const streamCount = 3
+	pattern := "bulk-stop-test-"
+
+	var names []string
+	for range streamCount {
+		name := helpers.NewTestStream(t, clientSet, func(def *mockv1.TestStreamDefinition) {
+			def.Spec.RunDuration = "15s"
+			def.Spec.Suspended = false
+			def.GenerateName = pattern
+		})
+		require.NotEmpty(t, name)
+		names = append(names, name)
+	}
+
+	streamingClientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+	c, err := client.New(kubeConfig, client.Options{})
+	require.NoError(t, err)
+
+	clientProvider := NewFakeClientProvider(streamingClientSet, c)
+	streamService := NewStreamService(clientProvider, NewUnstructuredReader(clientProvider))
+	err = streamService.Stop(t.Context(), &models.StopParameters{
+		Namespace:   "default",
+		StreamClass: "arcane-stream-mock",
+		Selection:   &models.StreamSelection{Prefix: pattern},
+	})
+	require.NoError(t, err)
+
+	for _, name := range names {
+		stream, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.True(t, stream.Spec.Suspended)
+	}
+}
+
+func Test_StreamStopped_RetriesOnConflict(t *testing.T) {
+	name := createTestStreamDefinition(t, false, "15s", false)
+	require.NotEmpty(t, name)
+	err := waitForPhase(t, name, streamapis.Running)
+	require.NoError(t, err)
+
+	streamingClientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+	c, err := client.NewWithWatch(kubeConfig, client.Options{})
+	require.NoError(t, err)
+
+	conflicts := 0
+	conflicting := interceptor.NewClient(c, interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if conflicts < 2 {
+				conflicts++
+				return apierrors.NewConflict(schema.GroupResource{Resource: "teststreamdefinitions"}, obj.GetName(), fmt.Errorf("conflict"))
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+	})
+
+	clientProvider := NewFakeClientProvider(streamingClientSet, conflicting)
+	streamService := NewStreamService(clientProvider, NewUnstructuredReader(clientProvider))
+	err = streamService.Stop(t.Context(), &models.StopParameters{
+		Namespace:   "default",
+		StreamId:    name,
+		StreamClass: "arcane-stream-mock",
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, conflicts)
+
+	stream, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.True(t, stream.Spec.Suspended)
+}
+
+func Test_StreamStopped_ConflictRetriesExhausted(t *testing.T) {
+	name := createTestStreamDefinition(t, false, "15s", false)
+	require.NotEmpty(t, name)
+	err := waitForPhase(t, name, streamapis.Running)
+	require.NoError(t, err)
+
+	streamingClientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+	c, err := client.NewWithWatch(kubeConfig, client.Options{})
+	require.NoError(t, err)
+
+	conflicting := interceptor.NewClient(c, interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			return apierrors.NewConflict(schema.GroupResource{Resource: "teststreamdefinitions"}, obj.GetName(), fmt.Errorf("conflict"))
+		},
+	})
+
+	clientProvider := NewFakeClientProvider(streamingClientSet, conflicting)
+	streamService := NewStreamService(clientProvider, NewUnstructuredReader(clientProvider))
+	err = streamService.Stop(t.Context(), &models.StopParameters{
+		Namespace:   "default",
+		StreamId:    name,
+		StreamClass: "arcane-stream-mock",
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "was modified concurrently")
+
+	stream, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.False(t, stream.Spec.Suspended)
+}
+
+func Test_StreamStopped_DryRun(t *testing.T) {
+	for _, dryRun := range []models.DryRunStrategy{models.DryRunClient, models.DryRunServer} {
+		t.Run(string(dryRun), func(t *testing.T) {
+			name := createTestStreamDefinition(t, false, "15s", false)
+			require.NotEmpty(t, name)
+			err := waitForPhase(t, name, streamapis.Running)
+			require.NoError(t, err)
+
+			streamingClientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+			c, err := client.New(kubeConfig, client.Options{})
+			require.NoError(t, err)
+
+			clientProvider := NewFakeClientProvider(streamingClientSet, c)
+			streamService := NewStreamService(clientProvider, NewUnstructuredReader(clientProvider))
+			err = streamService.Stop(t.Context(), &models.StopParameters{
+				Namespace:   "default",
+				StreamId:    name,
+				StreamClass: "arcane-stream-mock",
+				DryRun:      dryRun,
+			})
+			require.NoError(t, err)
+
+			stream, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+			require.NoError(t, err)
+			require.False(t, stream.Spec.Suspended)
+		})
+	}
+}
+
+func Test_StreamStopped_Audit(t *testing.T) {
+	name := createTestStreamDefinition(t, false, "15s", false)
+	require.NotEmpty(t, name)
+	err := waitForPhase(t, name, streamapis.Running)
+	require.NoError(t, err)
+
+	streamingClientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+	c, err := client.New(kubeConfig, client.Options{})
+	require.NoError(t, err)
+
+	clientProvider := NewFakeClientProvider(streamingClientSet, c)
+	streamService := NewStreamService(clientProvider, NewUnstructuredReader(clientProvider))
+	err = streamService.Stop(t.Context(), &models.StopParameters{
+		Namespace:   "default",
+		StreamId:    name,
+		StreamClass: "arcane-stream-mock",
+		Audit:       models.AuditParameters{Reason: "source maintenance", KubeconfigUser: "kind-user"},
+	})
+	require.NoError(t, err)
+
+	stream, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.True(t, stream.Spec.Suspended)
+	require.Equal(t, "stream-stop", stream.Annotations[interfaces.LastOperationAnnotationKey])
+	require.Equal(t, "source maintenance", stream.Annotations[interfaces.ReasonAnnotationKey])
+	require.NotEmpty(t, stream.Annotations[interfaces.LastModifiedByAnnotationKey])
+	require.NotEmpty(t, stream.Annotations[interfaces.LastModifiedAtAnnotationKey])
+	require.NotContains(t, stream.Annotations, interfaces.TicketAnnotationKey)
+}
+
+func Test_StreamRebuild(t *testing.T) {
+	name := createTestStreamDefinition(t, false, "5s", false)
+	require.NotEmpty(t, name)
+
+	streamingClientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+	c, err := client.New(kubeConfig, client.Options{})
+	require.NoError(t, err)
+
+	clientProvider := NewFakeClientProvider(streamingClientSet, c)
+	rebuildService := NewStreamRebuildService(NewStreamService(clientProvider, NewUnstructuredReader(clientProvider)), newBackfillService(clientProvider), clientProvider, NewUnstructuredReader(clientProvider))
+	err = rebuildService.Rebuild(t.Context(), &models.RebuildParameters{
+		Namespace:     "default",
+		StreamClass:   "arcane-stream-mock",
+		StreamId:      name,
+		Timeout:       5 * time.Minute,
+		StreamTimeout: 2 * time.Minute,
+	})
+	require.NoError(t, err)
+
+	bfr, err := findBackfillRequestByName(t.Context(), "default", name)
+	require.NoError(t, err)
+	require.True(t, bfr.Spec.Completed)
+
+	stream, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.False(t, stream.Spec.Suspended)
+}
+
+func Test_StreamRebuild_SuspendedStream(t *testing.T) {
+	name := createTestStreamDefinition(t, false, "5s", true)
+	require.NotEmpty(t, name)
+
+	streamingClientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+	c, err := client.New(kubeConfig, client.Options{})
+	require.NoError(t, err)
+
+	clientProvider := NewFakeClientProvider(streamingClientSet, c)
+	rebuildService := NewStreamRebuildService(NewStreamService(clientProvider, NewUnstructuredReader(clientProvider)), newBackfillService(clientProvider), clientProvider, NewUnstructuredReader(clientProvider))
+	err = rebuildService.Rebuild(t.Context(), &models.RebuildParameters{
+		Namespace:     "default",
+		StreamClass:   "arcane-stream-mock",
+		StreamId:      name,
+		Timeout:       5 * time.Minute,
+		StreamTimeout: 2 * time.Minute,
+	})
+	require.NoError(t, err)
+
+	bfr, err := findBackfillRequestByName(t.Context(), "default", name)
+	require.NoError(t, err)
+	require.True(t, bfr.Spec.Completed)
+
+	stream, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.False(t, stream.Spec.Suspended, "a rebuild always ends with starting the stream")
+}
+
+func Test_StreamRebuild_RollbackOnFailedBackfill(t *testing.T) {
+	name := createTestStreamDefinition(t, true, "5s", false)
+	require.NotEmpty(t, name)
+
+	streamingClientSet := versionedv1.NewForConfigOrDie(kubeConfig)
+	c, err := client.New(kubeConfig, client.Options{})
+	require.NoError(t, err)
+
+	clientProvider := NewFakeClientProvider(streamingClientSet, c)
+	rebuildService := NewStreamRebuildService(NewStreamService(clientProvider, NewUnstructuredReader(clientProvider)), newBackfillService(clientProvider), clientProvider, NewUnstructuredReader(clientProvider))
+	err = rebuildService.Rebuild(t.Context(), &models.RebuildParameters{
+		Namespace:     "default",
+		StreamClass:   "arcane-stream-mock",
+		StreamId:      name,
+		Timeout:       5 * time.Minute,
+		StreamTimeout: 2 * time.Minute,
+	})
+	require.ErrorContains(t, err, "error backfilling stream")
+	require.Equal(t, errors.ExitCodeFailed, errors.ExitCode(err))
+
+	stream, err := clientSet.StreamingV1().TestStreamDefinitions("default").Get(t.Context(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.False(t, stream.Spec.Suspended, "a stream running before the rebuild must not be left suspended")
+}
@@ -39,6 +39,11 @@ func (b *validatedBackfill) Backfill(ctx context.Context, parameters *models.Bac
 		return fmt.Errorf("validatedBackfill: error getting stream class: %w", err)
 	}
 
+	// The streams of a selection are listed from the stream class, so only a single stream must be validated
+	if parameters.Selection != nil {
+		return b.backfillService.Backfill(ctx, parameters)
+	}
+
 	unstructuredClient, err := b.clientProvider.ProvideUnstructuredClient()
 	if err != nil {
 		return fmt.Errorf("validatedBackfill: error creating unstructured client: %w", err)
@@ -55,3 +60,15 @@ func (b *validatedBackfill) Backfill(ctx context.Context, parameters *models.Bac
 
 	return b.backfillService.Backfill(ctx, parameters)
 }
+
+func (b *validatedBackfill) List(ctx context.Context, parameters *models.BackfillListParameters) (interfaces.BackfillInventory, error) {
+	return b.backfillService.List(ctx, parameters)
+}
+
+func (b *validatedBackfill) Cancel(ctx context.Context, parameters *models.BackfillCancelParameters) error {
+	return b.backfillService.Cancel(ctx, parameters)
+}
+
+func (b *validatedBackfill) GC(ctx context.Context, parameters *models.BackfillGCParameters) error {
+	return b.backfillService.GC(ctx, parameters)
+}
@@ -44,6 +44,45 @@ func Test_Stop(t *testing.T) {
 	)
 }
 
+func Test_Stop_Reason(t *testing.T) {
+	runIntegrationTest(t,
+		func(def *mockv1.TestStreamDefinition) {
+			def.Namespace = "integration-tests"
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = false
+			def.Spec.ShouldFail = false
+			def.GenerateName = "integration-test-stop-reason-"
+		},
+		"kubectl arcane stream stop arcane-stream-mock %s --namespace integration-tests --reason source-maintenance --ticket OPS-1",
+	)
+}
+
+func Test_Stop_Wait(t *testing.T) {
+	runIntegrationTest(t,
+		func(def *mockv1.TestStreamDefinition) {
+			def.Namespace = "integration-tests"
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = false
+			def.Spec.ShouldFail = false
+			def.GenerateName = "integration-test-stop-wait-"
+		},
+		"kubectl arcane stream stop arcane-stream-mock %s --wait --timeout 2m --namespace integration-tests",
+	)
+}
+
+func Test_Stop_Bulk(t *testing.T) {
+	runIntegrationTest(t,
+		func(def *mockv1.TestStreamDefinition) {
+			def.Namespace = "integration-tests"
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = false
+			def.Spec.ShouldFail = false
+			def.GenerateName = "integration-test-stop-bulk-"
+		},
+		"kubectl arcane stream stop arcane-stream-mock --prefix integration-test-stop-bulk- --namespace integration-tests",
+	)
+}
+
 func Test_Backfill(t *testing.T) {
 	runIntegrationTest(t,
 		func(def *mockv1.TestStreamDefinition) {
@@ -57,6 +96,19 @@ func Test_Backfill(t *testing.T) {
 	)
 }
 
+func Test_Backfill_Bulk(t *testing.T) {
+	runIntegrationTest(t,
+		func(def *mockv1.TestStreamDefinition) {
+			def.Namespace = "integration-tests"
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = true
+			def.Spec.ShouldFail = false
+			def.GenerateName = "integration-test-backfill-bulk-"
+		},
+		"kubectl arcane stream backfill arcane-stream-mock --prefix integration-test-backfill-bulk- --max-in-flight 2 --timeout 5m --namespace integration-tests",
+	)
+}
+
 func Test_Backfill_Wait(t *testing.T) {
 	runIntegrationTest(t,
 		func(def *mockv1.TestStreamDefinition) {
@@ -70,6 +122,45 @@ func Test_Backfill_Wait(t *testing.T) {
 	)
 }
 
+func Test_StreamRebuild(t *testing.T) {
+	runIntegrationTest(t,
+		func(def *mockv1.TestStreamDefinition) {
+			def.Namespace = "integration-tests"
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = false
+			def.Spec.ShouldFail = false
+			def.GenerateName = "integration-test-rebuild-"
+		},
+		"kubectl arcane stream rebuild arcane-stream-mock %s --timeout 5m --namespace integration-tests",
+	)
+}
+
+func Test_BackfillList(t *testing.T) {
+	runIntegrationTest(t,
+		func(def *mockv1.TestStreamDefinition) {
+			def.Namespace = "integration-tests"
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = true
+			def.Spec.ShouldFail = false
+			def.GenerateName = "integration-backfill-list-"
+		},
+		"kubectl arcane backfill list --stream-id %s --namespace integration-tests",
+	)
+}
+
+func Test_BackfillGC(t *testing.T) {
+	runIntegrationTest(t,
+		func(def *mockv1.TestStreamDefinition) {
+			def.Namespace = "integration-tests"
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = true
+			def.Spec.ShouldFail = false
+			def.GenerateName = "integration-backfill-gc-"
+		},
+		"kubectl arcane backfill gc --older-than 7d --keep-last 1 --dry-run --namespace integration-tests",
+	)
+}
+
 func Test_DowntimeDeclare(t *testing.T) {
 	runIntegrationTest(t,
 		func(def *mockv1.TestStreamDefinition) {
@@ -83,6 +174,72 @@ func Test_DowntimeDeclare(t *testing.T) {
 	)
 }
 
+func Test_DowntimeDeclare_DryRun(t *testing.T) {
+	runIntegrationTest(t,
+		func(def *mockv1.TestStreamDefinition) {
+			def.Namespace = "integration-tests"
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = false
+			def.Spec.ShouldFail = false
+			def.GenerateName = "integration-downtime-dry-run-"
+		},
+		"kubectl arcane downtime declare arcane-stream-mock %s downtime-window-dry-run --dry-run=server --namespace integration-tests",
+	)
+}
+
+func Test_DowntimeDeclare_Selection(t *testing.T) {
+	runIntegrationTest(t,
+		func(def *mockv1.TestStreamDefinition) {
+			def.Namespace = "integration-tests"
+			def.Labels = map[string]string{"team": "integration"}
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = false
+			def.Spec.ShouldFail = false
+			def.GenerateName = "integration-downtime-selection-"
+		},
+		"kubectl arcane downtime declare arcane-stream-mock downtime-window-selection --selector team=integration --regex ^%s$ --exclude ^integration-downtime-declare- --namespace integration-tests",
+	)
+}
+
+func Test_DowntimeDeclare_AllStreamClasses(t *testing.T) {
+	runIntegrationTest(t,
+		func(def *mockv1.TestStreamDefinition) {
+			def.Namespace = "integration-tests"
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = false
+			def.Spec.ShouldFail = false
+			def.GenerateName = "integration-downtime-all-classes-"
+		},
+		"kubectl arcane downtime declare downtime-window-all-classes --prefix %s -A",
+	)
+}
+
+func Test_DowntimeDeclare_Concurrency(t *testing.T) {
+	runIntegrationTest(t,
+		func(def *mockv1.TestStreamDefinition) {
+			def.Namespace = "integration-tests"
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = false
+			def.Spec.ShouldFail = false
+			def.GenerateName = "integration-downtime-concurrency-"
+		},
+		"kubectl arcane downtime declare arcane-stream-mock %s downtime-window-concurrency --concurrency 4 --qps 20 --burst 40 --namespace integration-tests",
+	)
+}
+
+func Test_DowntimeDeclare_ChunkSize(t *testing.T) {
+	runIntegrationTest(t,
+		func(def *mockv1.TestStreamDefinition) {
+			def.Namespace = "integration-tests"
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = false
+			def.Spec.ShouldFail = false
+			def.GenerateName = "integration-downtime-chunk-size-"
+		},
+		"kubectl arcane downtime declare arcane-stream-mock %s downtime-window-chunk-size --chunk-size 1 --namespace integration-tests",
+	)
+}
+
 func Test_DowntimeStop(t *testing.T) {
 	runIntegrationTest(t,
 		func(def *mockv1.TestStreamDefinition) {
@@ -102,6 +259,46 @@ func Test_DowntimeStop(t *testing.T) {
 	)
 }
 
+func Test_DowntimeStop_OverlappingDowntimes(t *testing.T) {
+	runIntegrationTest(t,
+		func(def *mockv1.TestStreamDefinition) {
+			def.Namespace = "integration-tests"
+			def.Labels = map[string]string{
+				interfaces.DowntimeLabelKey: "overlapping-window-1",
+			}
+			def.Annotations = map[string]string{
+				interfaces.DowntimeKeysAnnotationKey:  "overlapping-window-1,overlapping-window-2",
+				interfaces.DowntimeBeginAnnotationKey: time.Now().UTC().Format(time.RFC3339),
+			}
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = true
+			def.Spec.ShouldFail = false
+			def.GenerateName = "integration-downtime-overlapping-"
+		},
+		"kubectl arcane downtime stop arcane-stream-mock overlapping-window-1 --namespace integration-tests",
+	)
+}
+
+func Test_DowntimeExpire(t *testing.T) {
+	runIntegrationTest(t,
+		func(def *mockv1.TestStreamDefinition) {
+			def.Namespace = "integration-tests"
+			def.Labels = map[string]string{
+				interfaces.DowntimeLabelKey: "maintenance-window-expired",
+			}
+			def.Annotations = map[string]string{
+				interfaces.DowntimeBeginAnnotationKey:  time.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+				interfaces.DowntimeExpiryAnnotationKey: time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+			}
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = true
+			def.Spec.ShouldFail = false
+			def.GenerateName = "integration-downtime-expire-"
+		},
+		"kubectl arcane downtime expire --stream-class arcane-stream-mock",
+	)
+}
+
 func Test_DowntimeList(t *testing.T) {
 	runIntegrationTest(t,
 		func(def *mockv1.TestStreamDefinition) {
@@ -140,6 +337,64 @@ func Test_DowntimeDetails(t *testing.T) {
 	)
 }
 
+func Test_StreamList(t *testing.T) {
+	runIntegrationTest(t,
+		func(def *mockv1.TestStreamDefinition) {
+			def.Namespace = "integration-tests"
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = true
+			def.Spec.ShouldFail = false
+			def.GenerateName = "integration-stream-list-"
+		},
+		"kubectl arcane stream list -A",
+	)
+}
+
+func Test_StreamList_CustomColumns(t *testing.T) {
+	runIntegrationTest(t,
+		func(def *mockv1.TestStreamDefinition) {
+			def.Namespace = "integration-tests"
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = true
+			def.Spec.ShouldFail = false
+			def.GenerateName = "integration-stream-list-columns-"
+		},
+		"kubectl arcane stream list -A -o custom-columns=NAME:.metadata.name,PHASE:.status.phase",
+	)
+}
+
+func Test_DowntimeList_Json(t *testing.T) {
+	runIntegrationTest(t,
+		func(def *mockv1.TestStreamDefinition) {
+			def.Namespace = "integration-tests"
+			def.Labels = map[string]string{
+				interfaces.DowntimeLabelKey: "maintenance-window-json",
+			}
+			def.Annotations = map[string]string{
+				interfaces.DowntimeBeginAnnotationKey: time.Now().UTC().Format(time.RFC3339),
+			}
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = true
+			def.Spec.ShouldFail = false
+			def.GenerateName = "integration-downtime-list-json-"
+		},
+		"kubectl arcane downtime list -o json",
+	)
+}
+
+func Test_StreamDescribe(t *testing.T) {
+	runIntegrationTest(t,
+		func(def *mockv1.TestStreamDefinition) {
+			def.Namespace = "integration-tests"
+			def.Spec.RunDuration = "5s"
+			def.Spec.Suspended = false
+			def.Spec.ShouldFail = false
+			def.GenerateName = "integration-stream-describe-"
+		},
+		"kubectl arcane stream describe arcane-stream-mock %s --namespace integration-tests",
+	)
+}
+
 var (
 	clientSet     *mockversionedv1.Clientset
 	kubeconfigCmd string